@@ -1,7 +1,7 @@
 package binance
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,15 +12,17 @@ import (
 type BinanceClient struct {
 	client *client
 	dialer *websocket.Dialer
+	wsOpts WSOpts
 }
 
 func NewBinanceClient(apikey, secret string) *BinanceClient {
 	return &BinanceClient{
 		client: &client{
-			window: 5000,
-			apikey: apikey,
-			secret: secret,
-			client: http.DefaultClient,
+			baseURL: url,
+			window:  5000,
+			apikey:  apikey,
+			secret:  secret,
+			client:  http.DefaultClient,
 		},
 		dialer: websocket.DefaultDialer,
 	}
@@ -32,10 +34,11 @@ func NewBinanceClientWindow(apikey, secret string, window int) (*BinanceClient,
 	}
 	return &BinanceClient{
 		client: &client{
-			window: window,
-			apikey: apikey,
-			secret: secret,
-			client: http.DefaultClient,
+			baseURL: url,
+			window:  window,
+			apikey:  apikey,
+			secret:  secret,
+			client:  http.DefaultClient,
 		},
 		dialer: websocket.DefaultDialer,
 	}, nil
@@ -45,6 +48,20 @@ func (b *BinanceClient) SetHTTPClient(client *http.Client) {
 	b.client.client = client
 }
 
+// SetMaxResponseSize sets the maximal size in bytes of the responses read, beyond which requests fail
+// Remark: A size of zero or less restores the default of 64MiB
+func (b *BinanceClient) SetMaxResponseSize(size int64) {
+	b.client.maxResponseSize = size
+}
+
+// SetWSOpts sets the keepalive and staleness detection options of websockets opened from now on
+func (b *BinanceClient) SetWSOpts(opts *WSOpts) {
+	b.wsOpts = WSOpts{}
+	if opts != nil {
+		b.wsOpts = *opts
+	}
+}
+
 // General endpoints
 
 // Ping tests connectivity to the Rest API
@@ -55,12 +72,11 @@ func (b *BinanceClient) Ping() error {
 
 // Time tests connectivity to the Rest API and get the current server time
 func (b *BinanceClient) Time() (*ServerTime, error) {
-	res, err := b.client.do(http.MethodGet, "api/v1/time", nil, false, false)
-	if err != nil {
+	serverTime := &ServerTime{}
+	if err := b.client.doJSON(http.MethodGet, "api/v1/time", nil, false, false, serverTime); err != nil {
 		return nil, err
 	}
-	serverTime := &ServerTime{}
-	return serverTime, json.Unmarshal(res, serverTime)
+	return serverTime, nil
 }
 
 // Market Data endpoints
@@ -72,12 +88,10 @@ func (b *BinanceClient) Depth(opts *DepthOpts) (*Depth, error) {
 	if opts.Limit == 0 || opts.Limit > 100 {
 		opts.Limit = 100
 	}
-	res, err := b.client.do(http.MethodGet, "api/v1/depth", opts, false, false)
-	if err != nil {
-		return nil, err
-	}
 	depth := &Depth{}
-	return depth, json.Unmarshal(res, &depth)
+	return depth, b.doSBEOrJSON("api/v1/depth", "api/v3/depth", opts, false, depth, sbeTemplateDepth, func(d *sbeDecoder) {
+		d.depth(depth)
+	})
 }
 
 // AggregatedTrades gets compressed, aggregate trades.
@@ -91,12 +105,11 @@ func (b *BinanceClient) AggregatedTrades(opts *AggregatedTradeOpts) ([]*Aggregat
 	if opts.Limit == 0 || opts.Limit > 500 {
 		opts.Limit = 500
 	}
-	res, err := b.client.do(http.MethodGet, "api/v1/aggTrades", opts, false, false)
-	if err != nil {
+	trades := []*AggregatedTrade{}
+	if err := b.client.doJSON(http.MethodGet, "api/v1/aggTrades", opts, false, false, &trades); err != nil {
 		return nil, err
 	}
-	trades := []*AggregatedTrade{}
-	return trades, json.Unmarshal(res, &trades)
+	return trades, nil
 }
 
 // Klines returns kline/candlestick bars for a symbol. Klines are uniquely identified by their open time
@@ -110,12 +123,11 @@ func (b *BinanceClient) Klines(opts *KlinesOpts) ([]*Klines, error) {
 	if opts.Limit == 0 || opts.Limit > 500 {
 		opts.Limit = 500
 	}
-	res, err := b.client.do(http.MethodGet, "api/v1/klines", opts, false, false)
-	if err != nil {
-		return nil, err
-	}
 	klines := []*Klines{}
-	return klines, json.Unmarshal(res, &klines)
+	err := b.doSBEOrJSON("api/v1/klines", "api/v3/klines", opts, false, &klines, sbeTemplateKlines, func(d *sbeDecoder) {
+		d.klines(&klines)
+	})
+	return klines, err
 
 }
 
@@ -124,32 +136,29 @@ func (b *BinanceClient) Ticker(opts *TickerOpts) (*TickerStats, error) {
 	if opts == nil {
 		return nil, fmt.Errorf("opts is nil")
 	}
-	res, err := b.client.do(http.MethodGet, "api/v1/ticker/24hr", opts, false, false)
-	if err != nil {
+	tickerStats := &TickerStats{}
+	if err := b.client.doJSON(http.MethodGet, "api/v1/ticker/24hr", opts, false, false, tickerStats); err != nil {
 		return nil, err
 	}
-	tickerStats := &TickerStats{}
-	return tickerStats, json.Unmarshal(res, tickerStats)
+	return tickerStats, nil
 }
 
 // Prices calculates the latest price for all symbols
 func (b *BinanceClient) Prices() ([]*SymbolPrice, error) {
-	res, err := b.client.do(http.MethodGet, "api/v1/ticker/allPrices", nil, false, false)
-	if err != nil {
+	prices := []*SymbolPrice{}
+	if err := b.client.doJSON(http.MethodGet, "api/v1/ticker/allPrices", nil, false, false, &prices); err != nil {
 		return nil, err
 	}
-	prices := []*SymbolPrice{}
-	return prices, json.Unmarshal(res, &prices)
+	return prices, nil
 }
 
 // AllBookTickers returns best price/qty on the order book for all symbols
 func (b *BinanceClient) AllBookTickers() ([]*BookTicker, error) {
-	res, err := b.client.do(http.MethodGet, "api/v1/ticker/allBookTickers", nil, false, false)
-	if err != nil {
+	resp := []*BookTicker{}
+	if err := b.client.doJSON(http.MethodGet, "api/v1/ticker/allBookTickers", nil, false, false, &resp); err != nil {
 		return nil, err
 	}
-	resp := []*BookTicker{}
-	return resp, json.Unmarshal(res, &resp)
+	return resp, nil
 }
 
 // Signed endpoints, associated with an account
@@ -159,12 +168,11 @@ func (b *BinanceClient) NewOrder(opts *NewOrderOpts) (*NewOrder, error) {
 	if opts == nil {
 		return nil, fmt.Errorf("opts is nil")
 	}
-	res, err := b.client.do(http.MethodPost, "api/v3/order", opts, true, false)
-	if err != nil {
+	resp := &NewOrder{}
+	if err := b.client.doJSON(http.MethodPost, "api/v3/order", opts, true, false, resp); err != nil {
 		return nil, err
 	}
-	resp := &NewOrder{}
-	return resp, json.Unmarshal(res, resp)
+	return resp, nil
 }
 
 // NewOrderTest tests new order creation and signature/recvWindow long. Creates and validates a new order but does not send it into the matching engine
@@ -184,12 +192,10 @@ func (b *BinanceClient) QueryOrder(opts *QueryOrderOpts) (*QueryOrder, error) {
 	if opts.OrderID < 0 && opts.OrigClientOrderId == "" {
 		return nil, fmt.Errorf("order id must be set")
 	}
-	res, err := b.client.do(http.MethodGet, "api/v3/order", opts, true, false)
-	if err != nil {
-		return nil, err
-	}
 	resp := &QueryOrder{}
-	return resp, json.Unmarshal(res, resp)
+	return resp, b.doSBEOrJSON("api/v3/order", "api/v3/order", opts, true, resp, sbeTemplateOrder, func(d *sbeDecoder) {
+		d.order(resp)
+	})
 }
 
 // CancelOrder cancel an active order
@@ -200,12 +206,11 @@ func (b *BinanceClient) CancelOrder(opts *CancelOrderOpts) (*CancelOrder, error)
 	if opts.OrderID < 0 || (opts.OrigClientOrderId == "" && opts.NewClientOrderId == "") {
 		return nil, fmt.Errorf("order id must be set")
 	}
-	res, err := b.client.do(http.MethodDelete, "api/v3/order", opts, true, false)
-	if err != nil {
+	resp := &CancelOrder{}
+	if err := b.client.doJSON(http.MethodDelete, "api/v3/order", opts, true, false, resp); err != nil {
 		return nil, err
 	}
-	resp := &CancelOrder{}
-	return resp, json.Unmarshal(res, resp)
+	return resp, nil
 }
 
 // OpenOrders get all open orders on a symbol
@@ -213,12 +218,11 @@ func (b *BinanceClient) OpenOrders(opts *OpenOrdersOpts) ([]*QueryOrder, error)
 	if opts == nil {
 		return nil, fmt.Errorf("opts is nil")
 	}
-	res, err := b.client.do(http.MethodGet, "api/v3/openOrders", opts, true, false)
-	if err != nil {
+	resp := []*QueryOrder{}
+	if err := b.client.doJSON(http.MethodGet, "api/v3/openOrders", opts, true, false, &resp); err != nil {
 		return nil, err
 	}
-	resp := []*QueryOrder{}
-	return resp, json.Unmarshal(res, &resp)
+	return resp, nil
 }
 
 // AllOrders get all account orders; active, canceled, or filled
@@ -229,60 +233,115 @@ func (b *BinanceClient) AllOrders(opts *AllOrdersOpts) ([]*QueryOrder, error) {
 	if opts.Limit == 0 {
 		opts.Limit = 500
 	}
-	res, err := b.client.do(http.MethodGet, "api/v3/allOrders", opts, true, false)
-	if err != nil {
-		return nil, err
+	if opts.Limit > 1000 {
+		opts.Limit = 1000
 	}
 	resp := []*QueryOrder{}
-	return resp, json.Unmarshal(res, &resp)
+	if err := b.client.doJSON(http.MethodGet, "api/v3/allOrders", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AllOrdersHistory returns an iterator over all orders of the account on a symbol; active, canceled, or filled,
+// starting from the first order matching the given opts
+func (b *BinanceClient) AllOrdersHistory(opts *AllOrdersOpts) *AllOrdersIterator {
+	return newAllOrdersIterator(b, opts)
 }
 
 // Account get current account information
 func (b *BinanceClient) Account() (*AccountInfo, error) {
-	res, err := b.client.do(http.MethodGet, "api/v3/account", nil, true, false)
-	if err != nil {
+	return b.AccountWithOpts(nil)
+}
+
+// AccountWithOpts get current account information using the given opts
+func (b *BinanceClient) AccountWithOpts(opts *AccountOpts) (*AccountInfo, error) {
+	resp := &AccountInfo{}
+	if err := b.client.doJSON(http.MethodGet, "api/v3/account", opts, true, false, resp); err != nil {
 		return nil, err
 	}
-	resp := &AccountInfo{}
-	return resp, json.Unmarshal(res, &resp)
+	return resp, nil
+}
+
+// AccountCommission get current account commission rates for a specific symbol
+func (b *BinanceClient) AccountCommission(opts *AccountCommissionOpts) (*AccountCommission, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &AccountCommission{}
+	if err := b.client.doJSON(http.MethodGet, "api/v3/account/commission", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AccountStatus get current account status
+func (b *BinanceClient) AccountStatus() (*AccountStatus, error) {
+	resp := &AccountStatus{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/account/status", nil, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// APITradingStatus get the trading status of the API key
+func (b *BinanceClient) APITradingStatus() (*APITradingStatus, error) {
+	resp := &APITradingStatus{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/account/apiTradingStatus", nil, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// APIKeyPermissions get the permissions of the API key, e.g. whether it is allowed to trade or to withdraw
+func (b *BinanceClient) APIKeyPermissions() (*APIKeyPermissions, error) {
+	resp := &APIKeyPermissions{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/account/apiRestrictions", nil, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 // Trades get trades for a specific account and symbol
-func (b *BinanceClient) Trades(opts *TradesOpts) (*Trades, error) {
+func (b *BinanceClient) Trades(opts *TradesOpts) ([]*Trades, error) {
 	if opts == nil {
 		return nil, fmt.Errorf("opts is nil")
 	}
-	if opts.Limit == 0 || opts.Limit > 500 {
+	if opts.Limit == 0 {
 		opts.Limit = 500
 	}
-	res, err := b.client.do(http.MethodGet, "api/v3/myTrades", opts, true, false)
-	if err != nil {
+	if opts.Limit > 1000 {
+		opts.Limit = 1000
+	}
+	resp := []*Trades{}
+	if err := b.client.doJSON(http.MethodGet, "api/v3/myTrades", opts, true, false, &resp); err != nil {
 		return nil, err
 	}
-	resp := &Trades{}
-	return resp, json.Unmarshal(res, &resp)
+	return resp, nil
+}
+
+// TradesHistory returns an iterator over all trades of a specific account and symbol, starting from the first trade
+// matching the given opts
+func (b *BinanceClient) TradesHistory(opts *TradesOpts) *TradesIterator {
+	return newTradesIterator(b, opts)
 }
 
 func (b *BinanceClient) ExchangeInfo() (*ExchangeInfo, error) {
-	res, err := b.client.do(http.MethodGet, "api/v1/exchangeInfo", nil, false, false)
-	if err != nil {
-		return nil, err
-	}
 	resp := &ExchangeInfo{}
-	return resp, json.Unmarshal(res, &resp)
+	return resp, b.doSBEOrJSON("api/v1/exchangeInfo", "api/v3/exchangeInfo", nil, false, resp, sbeTemplateExchangeInfo, func(d *sbeDecoder) {
+		d.exchangeInfo(resp)
+	})
 }
 
 // User stream endpoint
 
 // Datastream starts a new user datastream
 func (b *BinanceClient) DataStream() (string, error) {
-	res, err := b.client.do(http.MethodPost, "api/v1/userDataStream", nil, false, true)
-	if err != nil {
+	resp := &Datastream{}
+	if err := b.client.doJSON(http.MethodPost, "api/v1/userDataStream", nil, false, true, resp); err != nil {
 		return "", err
 	}
-
-	resp := &Datastream{}
-	return resp.ListenKey, json.Unmarshal(res, &resp)
+	return resp.ListenKey, nil
 }
 
 // DataStreamKeepAlive pings the datastream key to prevent timeout
@@ -297,41 +356,210 @@ func (b *BinanceClient) DataStreamClose(listenKey string) error {
 	return err
 }
 
+// dial opens a websocket to the given address, carrying the given stream
+// Remark: Staleness detection is disabled for an empty stream name
+func (b *BinanceClient) dial(addr, stream string) (*wsWrapper, error) {
+	return b.client.dialWS(b.dialer, b.wsOpts, addr, stream)
+}
+
+// dialStream opens a websocket to the given stream
+func (b *BinanceClient) dialStream(stream string) (*wsWrapper, error) {
+	return b.dial(wsAddress+stream, stream)
+}
+
 // DepthWS opens websocket with depth updates for the given symbol
 func (b *BinanceClient) DepthWS(symbol string) (*DepthWS, error) {
-	addr := strings.ToLower(symbol) + "@depth"
-	conn, _, err := b.dialer.Dial(wsAddress+addr, nil)
+	ws, err := b.dialStream(DepthStream(symbol))
 	if err != nil {
 		return nil, err
 	}
-	return &DepthWS{wsWrapper{conn: conn}}, nil
+	return &DepthWS{ws}, nil
 }
 
 // KlinesWS opens websocket with klines updates for the given symbol with the given interval
 func (b *BinanceClient) KlinesWS(symbol string, interval KlineInterval) (*KlinesWS, error) {
-	addr := fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
-	conn, _, err := b.dialer.Dial(wsAddress+addr, nil)
+	ws, err := b.dialStream(KlinesStream(symbol, interval))
 	if err != nil {
 		return nil, err
 	}
-	return &KlinesWS{wsWrapper{conn: conn}}, nil
+	return &KlinesWS{ws}, nil
 }
 
 // TradesWS opens websocket with trades updates for the given symbol
 func (b *BinanceClient) TradesWS(symbol string) (*TradesWS, error) {
-	addr := strings.ToLower(symbol) + "@aggTrade"
-	conn, _, err := b.dialer.Dial(wsAddress+addr, nil)
+	ws, err := b.dialStream(TradesStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &TradesWS{ws}, nil
+}
+
+// DepthSpeedWS opens websocket with depth updates for the given symbol, pushed at the given update speed
+func (b *BinanceClient) DepthSpeedWS(symbol string, speed UpdateSpeed) (*DepthWS, error) {
+	ws, err := b.dialStream(DepthSpeedStream(symbol, speed))
+	if err != nil {
+		return nil, err
+	}
+	return &DepthWS{ws}, nil
+}
+
+// PartialDepthWS opens websocket with snapshots of the given number of top order book levels for the given symbol,
+// pushed at the given update speed
+func (b *BinanceClient) PartialDepthWS(symbol string, levels DepthLevels, speed UpdateSpeed) (*PartialDepthWS, error) {
+	if levels != DepthLevels5 && levels != DepthLevels10 && levels != DepthLevels20 {
+		return nil, fmt.Errorf("levels value is invalid")
+	}
+	ws, err := b.dialStream(PartialDepthStream(symbol, levels, speed))
+	if err != nil {
+		return nil, err
+	}
+	return &PartialDepthWS{ws}, nil
+}
+
+// RawTradesWS opens websocket with raw trades updates for the given symbol
+func (b *BinanceClient) RawTradesWS(symbol string) (*RawTradesWS, error) {
+	ws, err := b.dialStream(RawTradesStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &RawTradesWS{ws}, nil
+}
+
+// TickerWS opens websocket with 24 hour ticker statistics updates for the given symbol
+func (b *BinanceClient) TickerWS(symbol string) (*TickerWS, error) {
+	ws, err := b.dialStream(TickerStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &TickerWS{ws}, nil
+}
+
+// AllTickersWS opens websocket with 24 hour ticker statistics updates for all symbols
+func (b *BinanceClient) AllTickersWS() (*AllTickersWS, error) {
+	ws, err := b.dialStream(AllTickersStream())
+	if err != nil {
+		return nil, err
+	}
+	return &AllTickersWS{ws}, nil
+}
+
+// MiniTickerWS opens websocket with 24 hour mini ticker statistics updates for the given symbol
+func (b *BinanceClient) MiniTickerWS(symbol string) (*MiniTickerWS, error) {
+	ws, err := b.dialStream(MiniTickerStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &MiniTickerWS{ws}, nil
+}
+
+// AllMiniTickersWS opens websocket with 24 hour mini ticker statistics updates for all symbols
+func (b *BinanceClient) AllMiniTickersWS() (*AllMiniTickersWS, error) {
+	ws, err := b.dialStream(AllMiniTickersStream())
+	if err != nil {
+		return nil, err
+	}
+	return &AllMiniTickersWS{ws}, nil
+}
+
+// BookTickerWS opens websocket with best bid and ask updates for the given symbol
+func (b *BinanceClient) BookTickerWS(symbol string) (*BookTickerWS, error) {
+	ws, err := b.dialStream(BookTickerStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &BookTickerWS{ws}, nil
+}
+
+// RollingTickerWS opens websocket with ticker statistics updates over the given rolling window for the given symbol
+func (b *BinanceClient) RollingTickerWS(symbol string, window RollingWindow) (*RollingTickerWS, error) {
+	ws, err := b.dialStream(RollingTickerStream(symbol, window))
+	if err != nil {
+		return nil, err
+	}
+	return &RollingTickerWS{ws}, nil
+}
+
+// AvgPriceWS opens websocket with average price updates for the given symbol
+func (b *BinanceClient) AvgPriceWS(symbol string) (*AvgPriceWS, error) {
+	ws, err := b.dialStream(AvgPriceStream(symbol))
 	if err != nil {
 		return nil, err
 	}
-	return &TradesWS{wsWrapper{conn: conn}}, nil
+	return &AvgPriceWS{ws}, nil
 }
 
 // AccountInfoWS opens websocket with account info updates
 func (b *BinanceClient) AccountInfoWS(listenKey string) (*AccountInfoWS, error) {
-	conn, _, err := b.dialer.Dial(wsAddress+listenKey, nil)
+	ws, err := b.dial(wsAddress+listenKey, "")
+	if err != nil {
+		return nil, err
+	}
+	return &AccountInfoWS{ws}, nil
+}
+
+// CombinedWS opens a single websocket carrying updates of all the given streams
+// Remark: Stream names can be generated using the stream name functions, e.g. DepthStream, KlinesStream and TradesStream
+func (b *BinanceClient) CombinedWS(streams ...string) (*CombinedWS, error) {
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("at least one stream must be given")
+	}
+	stream := strings.Join(streams, "/")
+	ws, err := b.dial(wsCombinedAddress+stream, stream)
+	if err != nil {
+		return nil, err
+	}
+	return &CombinedWS{ws}, nil
+}
+
+// StreamConn opens a stream websocket, subscribed to the given streams, which allows changing the subscribed streams
+// while the connection is open. Incoming updates are routed to the given handlers
+// Remark: Stream connections are not re-dialed, even if reconnecting is enabled: once Done is closed, a new
+// connection must be opened
+func (b *BinanceClient) StreamConn(handlers *StreamHandlers, streams ...string) (*StreamConn, error) {
+	if handlers == nil {
+		return nil, fmt.Errorf("handlers is nil")
+	}
+	if len(streams) > maxStreamsPerConn {
+		return nil, fmt.Errorf("at most %d streams are allowed per connection", maxStreamsPerConn)
+	}
+	addr := wsStreamAddress
+	if len(streams) > 0 {
+		addr = wsCombinedAddress + strings.Join(streams, "/")
+	}
+	hooks := b.client.observers()
+	conn, _, err := b.dialer.Dial(addr, nil)
+	hooks.connect(addr, err)
 	if err != nil {
 		return nil, err
 	}
-	return &AccountInfoWS{wsWrapper{conn: conn}}, nil
+	return newStreamConn(conn, addr, handlers, streams, b.wsOpts, hooks, b.client.logger), nil
+}
+
+// UserStream opens a user data stream which manages its datastream key: the key is created, kept alive, recreated
+// when it expires and closed once the given context is cancelled
+func (b *BinanceClient) UserStream(ctx context.Context, opts *UserStreamOpts) (*UserStream, error) {
+	u := newUserStream(ctx, b, opts, b.client.logger)
+	if err := u.connect(); err != nil {
+		return nil, err
+	}
+	u.start()
+	return u, nil
+}
+
+// WSAPI opens a websocket API connection, over which orders can be sent and queried without a new HTTP request each
+// time. The session is logged on if the given options carry a private key
+func (b *BinanceClient) WSAPI(opts *WSAPIOpts) (*WSAPIConn, error) {
+	conn, _, err := b.dialer.Dial(wsAPIAddress, nil)
+	b.client.observers().connect(wsAPIAddress, err)
+	if err != nil {
+		return nil, err
+	}
+	w := newWSAPIConn(conn, b.client, opts, b.wsOpts)
+	if opts != nil && opts.PrivateKey != nil {
+		if _, err := w.Logon(); err != nil {
+			w.Close()
+			return nil, err
+		}
+	}
+	return w, nil
 }
@@ -117,6 +117,19 @@ func TestBinanceClient_Account(t *testing.T) {
 	require.NoError(t, e)
 }
 
+func TestBinanceClient_AccountWithOpts(t *testing.T) {
+	ctx := newBinanceCtx()
+	_, e := ctx.api.AccountWithOpts(&AccountOpts{OmitZeroBalances: true})
+	require.NoError(t, e)
+}
+
+func TestBinanceClient_APIKeyPermissions(t *testing.T) {
+	ctx := newBinanceCtx()
+	p, e := ctx.api.APIKeyPermissions()
+	require.NoError(t, e)
+	require.True(t, p.EnableReading)
+}
+
 func TestBinanceClient_DepthWS(t *testing.T) {
 	const symbol = "ETHBTC"
 	ctx := newBinanceCtx()
@@ -150,6 +163,19 @@ func TestBinanceClient_TradesWS(t *testing.T) {
 	require.Equal(t, symbol, u.Symbol)
 }
 
+func TestBinanceClient_CombinedWS(t *testing.T) {
+	const symbol = "ETHBTC"
+	ctx := newBinanceCtx()
+	ws, err := ctx.api.CombinedWS(TradesStream(symbol), KlinesStream(symbol, KlineInterval1m))
+	require.NoError(t, err)
+	defer ws.Close()
+	u, err := ws.Read()
+	require.NoError(t, err)
+	if u.Trades == nil && u.Klines == nil {
+		require.FailNow(t, "Expected to receive an update")
+	}
+}
+
 func TestBinanceClient_AccountInfoWS(t *testing.T) {
 	ctx := newBinanceCtx()
 	key, err := ctx.api.DataStream()
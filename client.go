@@ -1,47 +1,182 @@
 package binance
 
 import (
+	"bytes"
+	"compress/gzip"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/google/go-querystring/query"
-	"io/ioutil"
+	"io"
+	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	url       = "https://www.binance.com"
+	url       = "https://api.binance.com"
 	wsAddress = "wss://stream.binance.com:9443/ws/"
+
+	wsStreamAddress   = "wss://stream.binance.com:9443/stream"
+	wsCombinedAddress = wsStreamAddress + "?streams="
+
+	fapiURL             = "https://fapi.binance.com"
+	fapiWSAddress       = "wss://fstream.binance.com/ws/"
+	fapiWSStreamAddress = "wss://fstream.binance.com/stream"
+
+	dapiURL             = "https://dapi.binance.com"
+	dapiWSAddress       = "wss://dstream.binance.com/ws/"
+	dapiWSStreamAddress = "wss://dstream.binance.com/stream"
+
+	// defaultMaxResponseSize bounds the size of responses, with room for the exchange info of all symbols
+	defaultMaxResponseSize = 64 << 20
+	// bufferPoolMax is the capacity above which a response buffer is released rather than pooled
+	bufferPoolMax = 1 << 20
 )
 
 // client represents the actual HTTP client, that is being used to interact with binance API server
 type client struct {
-	apikey string
-	secret string
-	client *http.Client
-	window int
+	baseURL string // baseURL is the API server the endpoints are relative to
+	apikey  string
+	secret  string
+	client  *http.Client
+	window  int
+	sbe     bool // sbe indicates whether SBE encoded responses are requested from the endpoints which support them
+
+	maxResponseSize int64 // maxResponseSize bounds the size of responses, defaults to defaultMaxResponseSize
+
+	middlewares []Middleware // middlewares are the middlewares calls pass through, in order
+	handler     Handler      // handler passes calls through the middlewares, or is nil if there are none
+	wsHooks     wsHooks      // wsHooks observe the websockets opened by the client
+	logger      *slog.Logger // logger, if set, logs the calls and the websockets of the client
+}
+
+// APIError represents an error returned by the API in response to a request
+type APIError struct {
+	Status int    `json:"-"`    // Status is the HTTP status code of the response
+	Code   int    `json:"code"` // Code is the error code of the API
+	Msg    string `json:"msg"`  // Msg describes the error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status %d: code %d: %s", e.Status, e.Code, e.Msg)
 }
 
 // do invokes the given API command with the given data
 // sign indicates whether the api call should be done with signed payload
 // stream indicates if the request is stream related
 func (c *client) do(method, endpoint string, data interface{}, sign bool, stream bool) (response []byte, err error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	resp, err := c.call(method, endpoint, data, sign, stream, "application/json", buf)
+	if err != nil {
+		return nil, err
+	}
+	// The response outlives the pooled buffer, hence it is copied out at its exact size
+	return append([]byte{}, resp.Body...), nil
+}
+
+// doJSON invokes the given API command with the given data and unmarshals its JSON response into v
+// Remark: The response is decoded from a pooled buffer without being copied. Streaming it through a json.Decoder
+// would not save the buffer, as the decoder buffers a whole value before decoding it
+func (c *client) doJSON(method, endpoint string, data interface{}, sign bool, stream bool, v interface{}) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	resp, err := c.call(method, endpoint, data, sign, stream, "application/json", buf)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp.Body, v); err != nil {
+		c.logDecodeError(endpoint, err)
+		return err
+	}
+	return nil
+}
+
+// call passes the given API command with the given data, accepting the given content type, through the middlewares
+// of the client, reading its response into the given buffer
+func (c *client) call(method, endpoint string, data interface{}, sign bool, stream bool, accept string, buf *bytes.Buffer) (*Response, error) {
 	// Convert the given data to urlencoded format
 	values, err := query.Values(data)
 	if err != nil {
 		return nil, err
 	}
+	req := &Request{
+		Endpoint: endpoint,
+		Method:   method,
+		Signed:   sign,
+		Params:   redactParams(values),
+		values:   values,
+		stream:   stream,
+		accept:   accept,
+		buf:      buf,
+	}
+	handler := c.handler
+	if handler == nil {
+		handler = c.roundTrip
+	}
+	resp, err := handler(req)
+	if c.logger != nil {
+		c.logCall(req, resp, err)
+	}
+	return resp, err
+}
+
+// roundTrip sends the request of the given call and reads its response
+// Remark: Unsuccessful responses fail with an *APIError if their body carries one, or an error reporting their body
+func (c *client) roundTrip(req *Request) (*Response, error) {
+	start := time.Now()
+	httpResp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	resp := &Response{
+		Status:      httpResp.StatusCode,
+		ContentType: httpResp.Header.Get("Content-Type"),
+		UsedWeight:  headerCounts(httpResp.Header, "X-Mbx-Used-Weight-"),
+		OrderCount:  headerCounts(httpResp.Header, "X-Mbx-Order-Count-"),
+	}
+	// The buffer is reset, as middlewares may retry calls
+	req.buf.Reset()
+	err = c.readBody(httpResp, req.buf)
+	resp.Latency = time.Since(start)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body = req.buf.Bytes()
+	if resp.Status != http.StatusOK {
+		return resp, decodeError(resp)
+	}
+	return resp, nil
+}
+
+// decodeError decodes the error carried by the given unsuccessful response
+func decodeError(resp *Response) error {
+	if strings.HasPrefix(resp.ContentType, sbeContentType) {
+		return decodeSBEError(resp.Status, resp.Body)
+	}
+	apiErr := &APIError{Status: resp.Status}
+	if err := json.Unmarshal(resp.Body, apiErr); err != nil || apiErr.Code == 0 {
+		return fmt.Errorf("status %d: %v", resp.Status, string(resp.Body))
+	}
+	return apiErr
+}
 
-	payload := values.Encode()
+// send sends the request of the given call
+// Remark: The body of the returned response must be closed
+func (c *client) send(r *Request) (*http.Response, error) {
+	payload := r.values.Encode()
 	// Signed requests require the additional timestamp, window size and signature of the payload
 	// Remark: This is done only to routes with actual data
-	if sign {
+	if r.Signed {
 		payload = fmt.Sprintf("%s&timestamp=%v&recvWindow=%d", payload, time.Now().UnixNano()/(1000*1000), c.window)
 		mac := hmac.New(sha256.New, []byte(c.secret))
-		_, err = mac.Write([]byte(payload))
+		_, err := mac.Write([]byte(payload))
 		if err != nil {
 			return nil, err
 		}
@@ -52,30 +187,98 @@ func (c *client) do(method, endpoint string, data interface{}, sign bool, stream
 	// Remark: GET requests payload is as a query parameters
 	// POST requests payload is given as a body
 	var req *http.Request
-	if method == http.MethodGet {
-		req, err = http.NewRequest(method, fmt.Sprintf("%s/%s?%s", url, endpoint, payload), nil)
+	var err error
+	if r.Method == http.MethodGet {
+		req, err = http.NewRequest(r.Method, fmt.Sprintf("%s/%s?%s", c.baseURL, r.Endpoint, payload), nil)
 	} else {
-		req, err = http.NewRequest(method, fmt.Sprintf("%s/%s", url, endpoint), strings.NewReader(payload))
+		req, err = http.NewRequest(r.Method, fmt.Sprintf("%s/%s", c.baseURL, r.Endpoint), strings.NewReader(payload))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.Method != http.MethodGet {
 		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	}
 
-	if sign || stream {
+	if r.Signed || r.stream {
 		req.Header.Add("X-MBX-APIKEY", c.apikey)
 	}
 
-	req.Header.Add("Accept", "application/json")
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	req.Header.Add("Accept", r.accept)
+	if r.accept == sbeContentType {
+		req.Header.Add("X-MBX-SBE", sbeSchema)
 	}
+	// Setting Accept-Encoding disables the transparent decompression of the transport, which readBody takes over
+	req.Header.Add("Accept-Encoding", "gzip")
+	return c.client.Do(req)
+}
 
-	defer resp.Body.Close()
-	response, err = ioutil.ReadAll(resp.Body)
+// readBody reads the body of the given response into the given buffer, decompressing it if needed
+// Remark: Bodies exceeding the maximal response size fail rather than being read to their end
+func (c *client) readBody(resp *http.Response, buf *bytes.Buffer) error {
+	limit := c.maxResponseSize
+	if limit <= 0 {
+		limit = defaultMaxResponseSize
+	}
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := getGzipReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer gzipPool.Put(zr)
+		body = zr
+	} else if resp.ContentLength > 0 && resp.ContentLength <= limit {
+		buf.Grow(int(resp.ContentLength))
+	}
+	n, err := buf.ReadFrom(io.LimitReader(body, limit+1))
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if n > limit {
+		return fmt.Errorf("status %d: response exceeds %d bytes", resp.StatusCode, limit)
+	}
+	return nil
+}
+
+var (
+	// bufferPool holds the buffers responses are read into
+	bufferPool = sync.Pool{New: func() interface{} { return &bytes.Buffer{} }}
+	// gzipPool holds the readers of compressed responses
+	gzipPool sync.Pool
+)
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer pools the given buffer, unless it grew too large to be kept around
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > bufferPoolMax {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+func getGzipReader(r io.Reader) (*gzip.Reader, error) {
+	if zr, ok := gzipPool.Get().(*gzip.Reader); ok {
+		return zr, zr.Reset(r)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status %d: %v", resp.StatusCode, string(response))
+	return gzip.NewReader(r)
+}
+
+// doSBE invokes the given API command requesting an SBE encoded response
+// sbe indicates whether the response is SBE encoded, as the server may still respond with JSON
+// Remark: errSBEUnsupported is returned when the server does not support the requested schema
+func (c *client) doSBE(method, endpoint string, data interface{}, sign bool) (response []byte, sbe bool, err error) {
+	resp, err := c.call(method, endpoint, data, sign, false, sbeContentType, &bytes.Buffer{})
+	if apiErr, ok := err.(*APIError); ok && sbeUnsupportedCodes[apiErr.Code] {
+		return nil, false, errSBEUnsupported
+	}
+	if err != nil {
+		return nil, false, err
 	}
-	return response, err
+	return resp.Body, strings.HasPrefix(resp.ContentType, sbeContentType), nil
 }
@@ -0,0 +1,141 @@
+package binance
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends all requests to the given server rather than to the exchange
+type redirectTransport struct {
+	server *neturl.URL
+}
+
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = r.server.Scheme
+	req.URL.Host = r.server.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestHTTPClient returns an http client which requests are served by the given handler
+func newTestHTTPClient(t *testing.T, handler http.HandlerFunc) *http.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	u, err := neturl.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Transport: &redirectTransport{server: u}}
+}
+
+// newTestClient returns a client which requests are served by the given handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *BinanceClient {
+	client := NewBinanceClient("key", "secret")
+	client.SetHTTPClient(newTestHTTPClient(t, handler))
+	return client
+}
+
+func TestClient_Gzip(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`[{"symbol":"ETHBTC","price":"0.06"},{"symbol":"LTCBTC","price":"0.002"}]`))
+		zw.Close()
+	})
+	// The pooled readers and buffers are reused across requests
+	for i := 0; i < 3; i++ {
+		prices, err := client.Prices()
+		require.NoError(t, err)
+		require.Equal(t, []*SymbolPrice{{Symbol: "ETHBTC", Price: "0.06"}, {Symbol: "LTCBTC", Price: "0.002"}}, prices)
+	}
+}
+
+func TestClient_MaxResponseSize(t *testing.T) {
+	body := `[{"symbol":"ETHBTC","price":"0.06"}]`
+	gzipped := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !gzipped {
+			w.Write([]byte(body))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(body))
+		zw.Close()
+	})
+	client.SetMaxResponseSize(int64(len(body)))
+	_, err := client.Prices()
+	require.NoError(t, err)
+
+	client.SetMaxResponseSize(int64(len(body)) - 1)
+	_, err = client.Prices()
+	require.EqualError(t, err, "status 200: response exceeds 35 bytes")
+
+	// The size applies to the decompressed body
+	gzipped = true
+	_, err = client.Prices()
+	require.EqualError(t, err, "status 200: response exceeds 35 bytes")
+
+	client.SetMaxResponseSize(0)
+	_, err = client.Prices()
+	require.NoError(t, err)
+}
+
+func TestClient_ErrorBody(t *testing.T) {
+	body := `{"code":-1121,"msg":"Invalid symbol."}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusBadRequest)
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(body))
+		zw.Close()
+	})
+	_, err := client.AllOrders(&AllOrdersOpts{Symbol: "ETHBTC"})
+	require.Equal(t, &APIError{Status: http.StatusBadRequest, Code: -1121, Msg: "Invalid symbol."}, err)
+	_, err = client.Ticker(&TickerOpts{Symbol: "ETHBTC"})
+	require.EqualError(t, err, "status 400: code -1121: Invalid symbol.")
+
+	// Bodies which carry no API error are reported as is
+	body = "<html>Bad Gateway</html>"
+	_, err = client.Ticker(&TickerOpts{Symbol: "ETHBTC"})
+	require.EqualError(t, err, "status 400: <html>Bad Gateway</html>")
+}
+
+func TestClient_BufferPool(t *testing.T) {
+	// Buffers grown by large responses are released rather than retained by the pool
+	large := getBuffer()
+	large.Grow(2 * bufferPoolMax)
+	putBuffer(large)
+	for i := 0; i < 10; i++ {
+		buf := getBuffer()
+		require.True(t, buf != large)
+		require.True(t, buf.Cap() <= bufferPoolMax)
+		defer putBuffer(buf)
+	}
+}
+
+func BenchmarkClient_AllOrders(b *testing.B) {
+	order := `{"symbol":"LTCBTC","orderId":1,"clientOrderId":"myOrder1","price":"0.1","origQty":"1.0","executedQty":"0.0",` +
+		`"status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","stopPrice":"0.0","icebergQty":"0.0","time":1499827319559}`
+	body := []byte("[" + strings.Repeat(order+",", 999) + order + "]")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer server.Close()
+	u, _ := neturl.Parse(server.URL)
+	client := NewBinanceClient("key", "secret")
+	client.SetHTTPClient(&http.Client{Transport: &redirectTransport{server: u}})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.AllOrders(&AllOrdersOpts{Symbol: "LTCBTC"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package binance
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// DeliveryClient is a client of the COIN-M futures API
+// Remark: COIN-M order quantities and position amounts are in contracts, see DeliverySymbolInfo.ContractSize
+type DeliveryClient struct {
+	client *client
+	dialer *websocket.Dialer
+	wsOpts WSOpts
+}
+
+func NewDeliveryClient(apikey, secret string) *DeliveryClient {
+	return &DeliveryClient{
+		client: &client{
+			baseURL: dapiURL,
+			window:  5000,
+			apikey:  apikey,
+			secret:  secret,
+			client:  http.DefaultClient,
+		},
+		dialer: websocket.DefaultDialer,
+	}
+}
+
+func (d *DeliveryClient) SetHTTPClient(client *http.Client) {
+	d.client.client = client
+}
+
+// SetMaxResponseSize sets the maximal size in bytes of the responses read, beyond which requests fail
+// Remark: A size of zero or less restores the default of 64MiB
+func (d *DeliveryClient) SetMaxResponseSize(size int64) {
+	d.client.maxResponseSize = size
+}
+
+// SetWSOpts sets the keepalive and staleness detection options of websockets opened from now on
+func (d *DeliveryClient) SetWSOpts(opts *WSOpts) {
+	d.wsOpts = WSOpts{}
+	if opts != nil {
+		d.wsOpts = *opts
+	}
+}
+
+// Contracts returns the number of whole contracts worth at most the given notional in quote asset
+func (s *DeliverySymbolInfo) Contracts(notional string) (string, error) {
+	if s.ContractSize <= 0 {
+		return "", fmt.Errorf("contract size of %s is unknown", s.Symbol)
+	}
+	value, err := strconv.ParseFloat(notional, 64)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(int(math.Floor(value / float64(s.ContractSize)))), nil
+}
+
+// Notional returns the value in quote asset of the given number of contracts
+func (s *DeliverySymbolInfo) Notional(contracts int) string {
+	return strconv.Itoa(contracts * s.ContractSize)
+}
+
+// Market Data endpoints
+
+// ExchangeInfo get the COIN-M trading rules and contracts information
+func (d *DeliveryClient) ExchangeInfo() (*DeliveryExchangeInfo, error) {
+	resp := &DeliveryExchangeInfo{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/exchangeInfo", nil, false, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarkPrices get the mark prices and funding rates of contracts
+func (d *DeliveryClient) MarkPrices(opts *DeliveryMarkPriceOpts) ([]*MarkPrice, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*MarkPrice{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/premiumIndex", opts, false, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FundingRates get the funding rate history of a perpetual contract
+func (d *DeliveryClient) FundingRates(opts *DeliveryFundingRateOpts) ([]*FundingRate, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" {
+		return nil, fmt.Errorf("symbol is missing")
+	}
+	resp := []*FundingRate{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/fundingRate", opts, false, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Klines returns kline/candlestick bars for a contract. Klines are uniquely identified by their open time
+func (d *DeliveryClient) Klines(opts *KlinesOpts) ([]*Klines, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" || opts.Interval == "" {
+		return nil, fmt.Errorf("symbol or interval are missing")
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 500
+	}
+	if opts.Limit > 1500 {
+		opts.Limit = 1500
+	}
+	klines := []*Klines{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/klines", opts, false, false, &klines); err != nil {
+		return nil, err
+	}
+	return klines, nil
+}
+
+// DeliveryPrices get the settlement prices of the delivered quarterly contracts of a pair
+func (d *DeliveryClient) DeliveryPrices(opts *DeliveryPriceOpts) ([]*DeliveryPrice, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Pair == "" {
+		return nil, fmt.Errorf("pair is missing")
+	}
+	resp := []*DeliveryPrice{}
+	if err := d.client.doJSON(http.MethodGet, "futures/data/delivery-price", opts, false, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Signed endpoints, associated with an account
+
+// Account get the COIN-M account information
+func (d *DeliveryClient) Account() (*DeliveryAccount, error) {
+	resp := &DeliveryAccount{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/account", nil, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Positions get the account positions
+func (d *DeliveryClient) Positions(opts *DeliveryPositionOpts) ([]*DeliveryPosition, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*DeliveryPosition{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/positionRisk", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ChangeLeverage changes the initial leverage of a contract
+func (d *DeliveryClient) ChangeLeverage(opts *LeverageOpts) (*Leverage, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" || opts.Leverage <= 0 {
+		return nil, fmt.Errorf("symbol or leverage are missing")
+	}
+	resp := &Leverage{}
+	if err := d.client.doJSON(http.MethodPost, "dapi/v1/leverage", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ChangeMarginType changes the margin mode of a contract
+func (d *DeliveryClient) ChangeMarginType(opts *MarginTypeOpts) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" || opts.MarginType == "" {
+		return fmt.Errorf("symbol or margin type are missing")
+	}
+	_, err := d.client.do(http.MethodPost, "dapi/v1/marginType", opts, true, false)
+	return err
+}
+
+// NewOrder sends in a new COIN-M order
+// Remark: Quantity is the number of contracts, which must be a whole number
+func (d *DeliveryClient) NewOrder(opts *FuturesNewOrderOpts) (*DeliveryOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.ClosePosition && (opts.Quantity != "" || opts.ReduceOnly) {
+		return nil, fmt.Errorf("close position can't be used with quantity or reduce only")
+	}
+	if opts.Quantity != "" {
+		if contracts, err := strconv.ParseFloat(opts.Quantity, 64); err != nil || contracts < 1 || contracts != math.Trunc(contracts) {
+			return nil, fmt.Errorf("quantity must be a positive number of contracts")
+		}
+	}
+	resp := &DeliveryOrder{}
+	if err := d.client.doJSON(http.MethodPost, "dapi/v1/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// QueryOrder checks a COIN-M order's status
+func (d *DeliveryClient) QueryOrder(opts *FuturesQueryOrderOpts) (*DeliveryOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	resp := &DeliveryOrder{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CancelOrder cancel an active COIN-M order
+func (d *DeliveryClient) CancelOrder(opts *FuturesQueryOrderOpts) (*DeliveryOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	resp := &DeliveryOrder{}
+	if err := d.client.doJSON(http.MethodDelete, "dapi/v1/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// OpenOrders get all open COIN-M orders
+func (d *DeliveryClient) OpenOrders(opts *FuturesOpenOrdersOpts) ([]*DeliveryOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*DeliveryOrder{}
+	if err := d.client.doJSON(http.MethodGet, "dapi/v1/openOrders", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// User stream endpoint
+
+// DataStream starts a new COIN-M user datastream
+// Remark: If the account has an active datastream key, it is returned and its validity is extended
+func (d *DeliveryClient) DataStream() (string, error) {
+	resp := &Datastream{}
+	if err := d.client.doJSON(http.MethodPost, "dapi/v1/listenKey", nil, false, true, resp); err != nil {
+		return "", err
+	}
+	return resp.ListenKey, nil
+}
+
+// DataStreamKeepAlive pings the COIN-M datastream key to prevent timeout
+func (d *DeliveryClient) DataStreamKeepAlive() error {
+	_, err := d.client.do(http.MethodPut, "dapi/v1/listenKey", nil, false, true)
+	return err
+}
+
+// DataStreamClose closes the COIN-M datastream key
+func (d *DeliveryClient) DataStreamClose() error {
+	_, err := d.client.do(http.MethodDelete, "dapi/v1/listenKey", nil, false, true)
+	return err
+}
+
+// dial opens a websocket to the given address, carrying the given stream
+// Remark: Staleness detection is disabled for an empty stream name
+func (d *DeliveryClient) dial(addr, stream string) (*wsWrapper, error) {
+	return d.client.dialWS(d.dialer, d.wsOpts, addr, stream)
+}
+
+// dialStream opens a websocket to the given stream
+func (d *DeliveryClient) dialStream(stream string) (*wsWrapper, error) {
+	return d.dial(dapiWSAddress+stream, stream)
+}
+
+// DepthWS opens websocket with depth updates for the given contract
+func (d *DeliveryClient) DepthWS(symbol string) (*DepthWS, error) {
+	ws, err := d.dialStream(DepthStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &DepthWS{ws}, nil
+}
+
+// KlinesWS opens websocket with klines updates for the given contract with the given interval
+func (d *DeliveryClient) KlinesWS(symbol string, interval KlineInterval) (*KlinesWS, error) {
+	ws, err := d.dialStream(KlinesStream(symbol, interval))
+	if err != nil {
+		return nil, err
+	}
+	return &KlinesWS{ws}, nil
+}
+
+// TradesWS opens websocket with aggregated trades updates for the given contract
+func (d *DeliveryClient) TradesWS(symbol string) (*TradesWS, error) {
+	ws, err := d.dialStream(TradesStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &TradesWS{ws}, nil
+}
+
+// BookTickerWS opens websocket with best bid and ask updates for the given contract
+func (d *DeliveryClient) BookTickerWS(symbol string) (*BookTickerWS, error) {
+	ws, err := d.dialStream(BookTickerStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &BookTickerWS{ws}, nil
+}
+
+// CombinedWS opens a single websocket carrying updates of all the given COIN-M streams
+func (d *DeliveryClient) CombinedWS(streams ...string) (*CombinedWS, error) {
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("at least one stream must be given")
+	}
+	stream := strings.Join(streams, "/")
+	ws, err := d.dial(dapiWSStreamAddress+"?streams="+stream, stream)
+	if err != nil {
+		return nil, err
+	}
+	return &CombinedWS{ws}, nil
+}
+
+// UserDataWS opens websocket with COIN-M order and account updates
+// Remark: COIN-M user data events share the USD-M event types
+func (d *DeliveryClient) UserDataWS(listenKey string) (*FuturesUserDataWS, error) {
+	ws, err := d.dial(dapiWSAddress+listenKey, "")
+	if err != nil {
+		return nil, err
+	}
+	return &FuturesUserDataWS{ws}, nil
+}
@@ -0,0 +1,36 @@
+package binance
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestDeliveryNewOrder(t *testing.T) {
+	client := NewDeliveryClient("key", "secret")
+	client.SetHTTPClient(newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/dapi/v1/order", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "3", r.PostForm.Get("quantity"))
+		w.Write([]byte(`{"symbol":"BTCUSD_PERP","pair":"BTCUSD","orderId":20072994037,"status":"NEW","origQty":"3","cumBase":"0","cumQuote":"0"}`))
+	}))
+	_, err := client.NewOrder(&FuturesNewOrderOpts{Symbol: "BTCUSD_PERP", Side: OrderSideBuy, Type: FuturesOrderTypeMarket, Quantity: "0.5"})
+	require.Error(t, err)
+
+	order, err := client.NewOrder(&FuturesNewOrderOpts{Symbol: "BTCUSD_PERP", Side: OrderSideBuy, Type: FuturesOrderTypeMarket, Quantity: "3"})
+	require.NoError(t, err)
+	require.Equal(t, int64(20072994037), order.OrderID)
+	require.Equal(t, "BTCUSD", order.Pair)
+	require.Equal(t, "0", order.CumBase)
+}
+
+func TestDeliverySymbolContracts(t *testing.T) {
+	symbol := &DeliverySymbolInfo{Symbol: "BTCUSD_PERP", ContractSize: 100}
+	contracts, err := symbol.Contracts("1050")
+	require.NoError(t, err)
+	require.Equal(t, "10", contracts)
+	require.Equal(t, "1000", symbol.Notional(10))
+
+	_, err = (&DeliverySymbolInfo{Symbol: "BTCUSD_PERP"}).Contracts("1050")
+	require.Error(t, err)
+}
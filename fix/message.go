@@ -0,0 +1,269 @@
+// Package fix implements Binance FIX 4.4 sessions, mapping order-entry messages onto the binance package order types
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// soh is the FIX field delimiter
+const soh = '\x01'
+
+const beginString = "FIX.4.4"
+
+const (
+	maxBodyLength  = 1 << 20 // maxBodyLength is the maximal body length of a read message
+	maxFieldLength = 32      // maxFieldLength is the maximal length of the header and trailer fields read one by one
+)
+
+// Tag represents a FIX field tag
+type Tag int
+
+const (
+	TagBeginSeqNo      Tag = 7
+	TagBeginString     Tag = 8
+	TagBodyLength      Tag = 9
+	TagCheckSum        Tag = 10
+	TagClOrdID         Tag = 11
+	TagCumQty          Tag = 14
+	TagEndSeqNo        Tag = 16
+	TagLastPx          Tag = 31
+	TagLastQty         Tag = 32
+	TagMsgSeqNum       Tag = 34
+	TagMsgType         Tag = 35
+	TagNewSeqNo        Tag = 36
+	TagOrderID         Tag = 37
+	TagOrderQty        Tag = 38
+	TagOrdStatus       Tag = 39
+	TagOrdType         Tag = 40
+	TagOrigClOrdID     Tag = 41
+	TagPossDupFlag     Tag = 43
+	TagPrice           Tag = 44
+	TagRefSeqNum       Tag = 45
+	TagSenderCompID    Tag = 49
+	TagSendingTime     Tag = 52
+	TagSide            Tag = 54
+	TagSymbol          Tag = 55
+	TagTargetCompID    Tag = 56
+	TagText            Tag = 58
+	TagTimeInForce     Tag = 59
+	TagTransactTime    Tag = 60
+	TagRawDataLength   Tag = 95
+	TagRawData         Tag = 96
+	TagEncryptMethod   Tag = 98
+	TagStopPx          Tag = 99
+	TagHeartBtInt      Tag = 108
+	TagMaxFloor        Tag = 111
+	TagTestReqID       Tag = 112
+	TagGapFillFlag     Tag = 123
+	TagResetSeqNumFlag Tag = 141
+	TagExecType        Tag = 150
+	TagLeavesQty       Tag = 151
+	TagUsername        Tag = 553
+	TagErrorCode       Tag = 25016
+	TagCumQuoteQty     Tag = 25017
+	TagMessageHandling Tag = 25035
+)
+
+// Tags of the trigger of stop loss and take profit orders
+const (
+	TagTriggerType           Tag = 1100
+	TagTriggerAction         Tag = 1101
+	TagTriggerPrice          Tag = 1102
+	TagTriggerPriceType      Tag = 1107
+	TagTriggerPriceDirection Tag = 1109
+)
+
+// MsgType represents the type of a FIX message
+type MsgType string
+
+const (
+	MsgTypeHeartbeat          MsgType = "0"
+	MsgTypeTestRequest        MsgType = "1"
+	MsgTypeResendRequest      MsgType = "2"
+	MsgTypeReject             MsgType = "3"
+	MsgTypeSequenceReset      MsgType = "4"
+	MsgTypeLogout             MsgType = "5"
+	MsgTypeExecutionReport    MsgType = "8"
+	MsgTypeLogon              MsgType = "A"
+	MsgTypeNewOrderSingle     MsgType = "D"
+	MsgTypeOrderCancelRequest MsgType = "F"
+)
+
+// Field is a single tag value pair of a message
+type Field struct {
+	Tag   Tag
+	Value string
+}
+
+// Message is a FIX message, holding its fields in order without the BeginString, BodyLength and CheckSum fields
+type Message struct {
+	Fields []Field
+}
+
+// NewMessage returns a message of the given type
+func NewMessage(msgType MsgType) *Message {
+	return &Message{Fields: []Field{{TagMsgType, string(msgType)}}}
+}
+
+// Type returns the type of the message
+func (m *Message) Type() MsgType {
+	return MsgType(m.Get(TagMsgType))
+}
+
+// Get returns the value of the first field of the given tag, or an empty string if the message has none
+func (m *Message) Get(tag Tag) string {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Has indicates whether the message has a field of the given tag
+func (m *Message) Has(tag Tag) bool {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Int returns the integer value of the first field of the given tag, or zero if the message has none
+func (m *Message) Int(tag Tag) int {
+	n, _ := strconv.Atoi(m.Get(tag))
+	return n
+}
+
+// Set sets the value of the first field of the given tag, appending the field if the message has none
+func (m *Message) Set(tag Tag, value string) *Message {
+	for i := range m.Fields {
+		if m.Fields[i].Tag == tag {
+			m.Fields[i].Value = value
+			return m
+		}
+	}
+	m.Fields = append(m.Fields, Field{tag, value})
+	return m
+}
+
+// SetIfNotEmpty sets the value of the given tag unless the value is empty
+func (m *Message) SetIfNotEmpty(tag Tag, value string) *Message {
+	if value == "" {
+		return m
+	}
+	return m.Set(tag, value)
+}
+
+// Bytes encodes the message, adding its BeginString, BodyLength and CheckSum fields
+func (m *Message) Bytes() []byte {
+	body := &bytes.Buffer{}
+	for _, f := range m.Fields {
+		writeField(body, f.Tag, f.Value)
+	}
+	msg := &bytes.Buffer{}
+	writeField(msg, TagBeginString, beginString)
+	writeField(msg, TagBodyLength, strconv.Itoa(body.Len()))
+	msg.Write(body.Bytes())
+	writeField(msg, TagCheckSum, fmt.Sprintf("%03d", checksum(msg.Bytes())))
+	return msg.Bytes()
+}
+
+func (m *Message) String() string {
+	return string(bytes.Replace(m.Bytes(), []byte{soh}, []byte{'|'}, -1))
+}
+
+// ReadMessage reads and validates a single message from the given reader
+func ReadMessage(r *bufio.Reader) (*Message, error) {
+	begin, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	if begin.Tag != TagBeginString || begin.Value != beginString {
+		return nil, fmt.Errorf("unexpected begin string: %d=%s", begin.Tag, begin.Value)
+	}
+	length, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(length.Value)
+	if length.Tag != TagBodyLength || err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid body length: %d=%s", length.Tag, length.Value)
+	}
+	if n > maxBodyLength {
+		return nil, fmt.Errorf("body length %d exceeds %d bytes", n, maxBodyLength)
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	trailer, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	head := &bytes.Buffer{}
+	writeField(head, TagBeginString, begin.Value)
+	writeField(head, TagBodyLength, length.Value)
+	head.Write(body)
+	if trailer.Tag != TagCheckSum || trailer.Value != fmt.Sprintf("%03d", checksum(head.Bytes())) {
+		return nil, fmt.Errorf("invalid checksum: %d=%s", trailer.Tag, trailer.Value)
+	}
+	m := &Message{}
+	for _, raw := range bytes.Split(bytes.TrimSuffix(body, []byte{soh}), []byte{soh}) {
+		f, err := parseField(raw)
+		if err != nil {
+			return nil, err
+		}
+		m.Fields = append(m.Fields, f)
+	}
+	return m, nil
+}
+
+func readField(r *bufio.Reader) (Field, error) {
+	raw := make([]byte, 0, maxFieldLength)
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return Field{}, err
+		}
+		if c == soh {
+			return parseField(raw)
+		}
+		if len(raw) == maxFieldLength {
+			return Field{}, fmt.Errorf("field exceeds %d bytes", maxFieldLength)
+		}
+		raw = append(raw, c)
+	}
+}
+
+func parseField(raw []byte) (Field, error) {
+	i := bytes.IndexByte(raw, '=')
+	if i <= 0 {
+		return Field{}, fmt.Errorf("invalid field: %q", raw)
+	}
+	tag, err := strconv.Atoi(string(raw[:i]))
+	if err != nil {
+		return Field{}, fmt.Errorf("invalid field tag: %q", raw)
+	}
+	return Field{Tag(tag), string(raw[i+1:])}, nil
+}
+
+func writeField(b *bytes.Buffer, tag Tag, value string) {
+	b.WriteString(strconv.Itoa(int(tag)))
+	b.WriteByte('=')
+	b.WriteString(value)
+	b.WriteByte(soh)
+}
+
+func checksum(data []byte) int {
+	sum := 0
+	for _, c := range data {
+		sum += int(c)
+	}
+	return sum % 256
+}
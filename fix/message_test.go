@@ -0,0 +1,36 @@
+package fix
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	m := NewMessage(MsgTypeHeartbeat).Set(TagMsgSeqNum, "2").Set(TagTestReqID, "abc")
+	require.Equal(t, "8=FIX.4.4|9=18|35=0|34=2|112=abc|10=166|", m.String())
+
+	res, err := ReadMessage(bufio.NewReader(bytes.NewReader(m.Bytes())))
+	require.NoError(t, err)
+	require.Equal(t, m.Fields, res.Fields)
+	require.Equal(t, MsgTypeHeartbeat, res.Type())
+	require.Equal(t, 2, res.Int(TagMsgSeqNum))
+	require.False(t, res.Has(TagText))
+}
+
+func TestReadMessage_Invalid(t *testing.T) {
+	data := bytes.Replace(NewMessage(MsgTypeHeartbeat).Bytes(), []byte("35=0"), []byte("35=1"), 1)
+	_, err := ReadMessage(bufio.NewReader(bytes.NewReader(data)))
+	require.Error(t, err)
+
+	_, err = ReadMessage(bufio.NewReader(bytes.NewReader([]byte("8=FIX.4.2\x019=5\x0135=0\x0110=000\x01"))))
+	require.Error(t, err)
+
+	// Oversized bodies and fields fail before being read
+	_, err = ReadMessage(bufio.NewReader(bytes.NewReader([]byte("8=FIX.4.4\x019=2000000000\x0135=0\x01"))))
+	require.EqualError(t, err, "body length 2000000000 exceeds 1048576 bytes")
+	_, err = ReadMessage(bufio.NewReader(bytes.NewReader(append([]byte("8=FIX.4.4\x019="), bytes.Repeat([]byte("1"), 1<<20)...))))
+	require.EqualError(t, err, "field exceeds 32 bytes")
+}
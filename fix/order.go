@@ -0,0 +1,198 @@
+package fix
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/noypi/binance-api"
+)
+
+var (
+	sides = map[binance.OrderSide]string{
+		binance.OrderSideBuy:  "1",
+		binance.OrderSideSell: "2",
+	}
+	// orderTypes maps order types onto OrdType values. Stop loss and take profit orders share their OrdType, and are
+	// told apart by the direction of their trigger price
+	orderTypes = map[binance.OrderType]string{
+		binance.OrderTypeMarket:                "1",
+		binance.OrderTypeLimit:                 "2",
+		binance.OrderType("STOP_LOSS"):         "3",
+		binance.OrderType("STOP_LOSS_LIMIT"):   "4",
+		binance.OrderType("TAKE_PROFIT"):       "3",
+		binance.OrderType("TAKE_PROFIT_LIMIT"): "4",
+	}
+	// takeProfitTypes holds the order types triggered by a price move in favor of the order side
+	takeProfitTypes = map[binance.OrderType]bool{
+		binance.OrderType("TAKE_PROFIT"):       true,
+		binance.OrderType("TAKE_PROFIT_LIMIT"): true,
+	}
+	timesInForce = map[binance.TimeInForce]string{
+		binance.TimeInForceGTC:     "1",
+		binance.TimeInForceIOC:     "3",
+		binance.TimeInForce("FOK"): "4",
+	}
+	// orderStatuses maps both OrdStatus and ExecType values to the order status of the binance package
+	orderStatuses = map[string]binance.OrderStatus{
+		"0": binance.OrderStatusNew,
+		"1": binance.OrderStatusPartial,
+		"2": binance.OrderStatusFilled,
+		"4": binance.OrderStatusCanceled,
+		"5": binance.OrderStatusReplaced,
+		"6": binance.OrderStatusPending,
+		"8": binance.OrderStatusRejected,
+		"A": binance.OrderStatusPendingNew,
+		"C": binance.OrderStatusExpired,
+		"F": binance.OrderStatusTrade,
+	}
+)
+
+// NewOrderSingle maps the given order onto a NewOrderSingle message
+// Remark: FIX requires a client order ID, so one is generated when NewClientOrderId is not given. The stop price of
+// stop loss and take profit orders is sent as a trigger price on the last trade price
+func NewOrderSingle(opts *binance.NewOrderOpts) (*Message, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	side, ok := sides[opts.Side]
+	if !ok {
+		return nil, fmt.Errorf("unsupported order side: %s", opts.Side)
+	}
+	ordType, ok := orderTypes[opts.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported order type: %s", opts.Type)
+	}
+	m := NewMessage(MsgTypeNewOrderSingle).
+		Set(TagClOrdID, clientOrderID(opts.NewClientOrderId)).
+		Set(TagSymbol, opts.Symbol).
+		Set(TagSide, side).
+		Set(TagOrdType, ordType).
+		Set(TagOrderQty, opts.Quantity).
+		SetIfNotEmpty(TagPrice, opts.Price).
+		SetIfNotEmpty(TagMaxFloor, opts.IcebergQty)
+	if ordType == "3" || ordType == "4" {
+		if opts.StopPrice == "" {
+			return nil, fmt.Errorf("stop price is required for %s orders", opts.Type)
+		}
+		m.Set(TagTriggerType, "4").
+			Set(TagTriggerAction, "1").
+			Set(TagTriggerPrice, opts.StopPrice).
+			Set(TagTriggerPriceType, "2").
+			Set(TagTriggerPriceDirection, triggerDirection(opts.Side, takeProfitTypes[opts.Type]))
+	} else if opts.StopPrice != "" {
+		return nil, fmt.Errorf("stop price is not supported for %s orders", opts.Type)
+	}
+	if opts.TimeInForce != "" {
+		tif, ok := timesInForce[opts.TimeInForce]
+		if !ok {
+			return nil, fmt.Errorf("unsupported time in force: %s", opts.TimeInForce)
+		}
+		m.Set(TagTimeInForce, tif)
+	}
+	return m, nil
+}
+
+// OrderCancelRequest maps the given cancellation onto an OrderCancelRequest message
+func OrderCancelRequest(opts *binance.CancelOrderOpts) (*Message, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID == 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("either OrderID or OrigClientOrderId is required")
+	}
+	m := NewMessage(MsgTypeOrderCancelRequest).
+		Set(TagClOrdID, clientOrderID(opts.NewClientOrderId)).
+		Set(TagSymbol, opts.Symbol).
+		SetIfNotEmpty(TagOrigClOrdID, opts.OrigClientOrderId)
+	if opts.OrderID != 0 {
+		m.Set(TagOrderID, strconv.Itoa(opts.OrderID))
+	}
+	return m, nil
+}
+
+// ExecutionReport represents an ExecutionReport message
+type ExecutionReport struct {
+	Order             *binance.QueryOrder // Order is the state of the order after the execution
+	OrigClientOrderID string              // OrigClientOrderID is the client order ID of the order a cancellation was requested for
+	ExecutionType     binance.OrderStatus
+	LastQty           string // LastQty is the quantity of the last fill
+	LastPrice         string // LastPrice is the price of the last fill
+	LeavesQty         string // LeavesQty is the quantity open for further execution
+	ErrorCode         int    // ErrorCode is the API error code of a rejection
+	Text              string // Text is the reason of a rejection
+}
+
+// ParseExecutionReport maps the given ExecutionReport message onto the order types of the binance package
+func ParseExecutionReport(m *Message) (*ExecutionReport, error) {
+	if m.Type() != MsgTypeExecutionReport {
+		return nil, fmt.Errorf("unexpected message type: %s", m.Type())
+	}
+	order := &binance.QueryOrder{
+		Symbol:             m.Get(TagSymbol),
+		OrderID:            m.Int(TagOrderID),
+		OrderListID:        -1,
+		ClientOrderID:      m.Get(TagClOrdID),
+		Price:              m.Get(TagPrice),
+		OrigQty:            m.Get(TagOrderQty),
+		ExecutedQty:        m.Get(TagCumQty),
+		CumulativeQuoteQty: m.Get(TagCumQuoteQty),
+		Status:             orderStatuses[m.Get(TagOrdStatus)],
+		StopPrice:          m.Get(TagTriggerPrice),
+		IcebergQty:         m.Get(TagMaxFloor),
+	}
+	for side, v := range sides {
+		if v == m.Get(TagSide) {
+			order.Side = side
+		}
+	}
+	for tif, v := range timesInForce {
+		if v == m.Get(TagTimeInForce) {
+			order.TimeInForce = tif
+		}
+	}
+	switch m.Get(TagOrdType) {
+	case "1":
+		order.Type = binance.OrderTypeMarket
+	case "2":
+		order.Type = binance.OrderTypeLimit
+	case "3", "4":
+		order.Type = binance.OrderType("STOP_LOSS")
+		if m.Get(TagTriggerPriceDirection) != triggerDirection(order.Side, false) {
+			order.Type = binance.OrderType("TAKE_PROFIT")
+		}
+		if m.Get(TagOrdType) == "4" {
+			order.Type += "_LIMIT"
+		}
+	}
+	if t, err := time.Parse(timeFormat, m.Get(TagTransactTime)); err == nil {
+		order.UpdateTime = uint64(t.UnixNano() / int64(time.Millisecond))
+	}
+	return &ExecutionReport{
+		Order:             order,
+		OrigClientOrderID: m.Get(TagOrigClOrdID),
+		ExecutionType:     orderStatuses[m.Get(TagExecType)],
+		LastQty:           m.Get(TagLastQty),
+		LastPrice:         m.Get(TagLastPx),
+		LeavesQty:         m.Get(TagLeavesQty),
+		ErrorCode:         m.Int(TagErrorCode),
+		Text:              m.Get(TagText),
+	}, nil
+}
+
+// triggerDirection returns the TriggerPriceDirection of an order of the given side: stop losses are triggered by a
+// price move against the side, take profits by a move in favor of it
+func triggerDirection(side binance.OrderSide, takeProfit bool) string {
+	if (side == binance.OrderSideBuy) != takeProfit {
+		return "U"
+	}
+	return "D"
+}
+
+// clientOrderID returns the given client order ID, or a generated one when empty
+func clientOrderID(id string) string {
+	if id != "" {
+		return id
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
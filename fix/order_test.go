@@ -0,0 +1,55 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/noypi/binance-api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrderSingle_Triggers(t *testing.T) {
+	for _, c := range []struct {
+		orderType binance.OrderType
+		side      binance.OrderSide
+		ordType   string
+		direction string
+	}{
+		{binance.OrderType("STOP_LOSS"), binance.OrderSideSell, "3", "D"},
+		{binance.OrderType("STOP_LOSS_LIMIT"), binance.OrderSideBuy, "4", "U"},
+		{binance.OrderType("TAKE_PROFIT"), binance.OrderSideSell, "3", "U"},
+		{binance.OrderType("TAKE_PROFIT_LIMIT"), binance.OrderSideBuy, "4", "D"},
+	} {
+		m, err := NewOrderSingle(&binance.NewOrderOpts{
+			Symbol:     "ETHBTC",
+			Side:       c.side,
+			Type:       c.orderType,
+			Quantity:   "1",
+			StopPrice:  "0.05",
+			IcebergQty: "0.1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, c.ordType, m.Get(TagOrdType), c.orderType)
+		require.Equal(t, "4", m.Get(TagTriggerType))
+		require.Equal(t, "0.05", m.Get(TagTriggerPrice))
+		require.Equal(t, c.direction, m.Get(TagTriggerPriceDirection), c.orderType)
+		require.Equal(t, "0.1", m.Get(TagMaxFloor))
+		require.False(t, m.Has(TagStopPx))
+
+		// Execution reports map the order type back from the trigger direction
+		report := NewMessage(MsgTypeExecutionReport)
+		for _, tag := range []Tag{TagSymbol, TagSide, TagOrdType, TagTriggerPrice, TagTriggerPriceDirection, TagMaxFloor} {
+			report.Set(tag, m.Get(tag))
+		}
+		res, err := ParseExecutionReport(report)
+		require.NoError(t, err)
+		require.Equal(t, c.orderType, res.Order.Type)
+		require.Equal(t, c.side, res.Order.Side)
+		require.Equal(t, "0.05", res.Order.StopPrice)
+		require.Equal(t, "0.1", res.Order.IcebergQty)
+	}
+
+	_, err := NewOrderSingle(&binance.NewOrderOpts{Symbol: "ETHBTC", Side: binance.OrderSideBuy, Type: binance.OrderType("TAKE_PROFIT"), Quantity: "1"})
+	require.Error(t, err)
+	_, err = NewOrderSingle(&binance.NewOrderOpts{Symbol: "ETHBTC", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimit, Quantity: "1", StopPrice: "0.05"})
+	require.Error(t, err)
+}
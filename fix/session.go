@@ -0,0 +1,443 @@
+package fix
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/noypi/binance-api"
+)
+
+const (
+	OrderEntryAddress = "fix-oe.binance.com:9000" // OrderEntryAddress is the address of order entry sessions
+	DropCopyAddress   = "fix-dc.binance.com:9000" // DropCopyAddress is the address of drop copy sessions, which only receive execution reports
+
+	defaultTargetCompID = "SPOT"
+	defaultHeartBtInt   = 30
+
+	sendingTimeFormat = "20060102-15:04:05.000"
+	// timeFormat parses timestamps of any sub-second precision
+	timeFormat = "20060102-15:04:05"
+
+	logonTimeout  = 10 * time.Second
+	logoutTimeout = 5 * time.Second
+)
+
+// MessageHandling represents how the exchange processes the messages of a session
+type MessageHandling int
+
+const (
+	MessageHandlingUnordered  MessageHandling = 1 // MessageHandlingUnordered processes messages in parallel
+	MessageHandlingSequential MessageHandling = 2 // MessageHandlingSequential processes messages in the order they are sent
+)
+
+// SessionOpts configures FIX sessions
+type SessionOpts struct {
+	APIKey            string
+	PrivateKey        ed25519.PrivateKey // PrivateKey signs the logon, the API key must be an Ed25519 key
+	SenderCompID      string             // SenderCompID identifies the session, and must be unique per API key
+	TargetCompID      string             // TargetCompID defaults to SPOT
+	HeartBtInt        int                // HeartBtInt is the heartbeat interval in seconds, defaults to 30
+	MessageHandling   MessageHandling    // MessageHandling defaults to MessageHandlingUnordered
+	OnExecutionReport func(report *ExecutionReport)
+	OnMessage         func(m *Message) // OnMessage, if set, handles the application messages other than execution reports
+	OnError           func(err error)  // OnError, if set, handles rejects, parse errors and the error that terminated the session
+}
+
+// Session is a logged on FIX session
+// Remark: Only the FIX session layer is handled by the session, orders are acknowledged and filled by the execution
+// reports delivered to the OnExecutionReport handler
+type Session struct {
+	conn net.Conn
+	r    *bufio.Reader
+	opts SessionOpts
+
+	mu       sync.Mutex // mu guards the outgoing sequence and serializes messages sent over the connection
+	outSeq   int
+	lastSent time.Time
+
+	stateMu    sync.Mutex
+	lastRecv   time.Time
+	testReqID  string // testReqID is the ID of the pending test request
+	loggingOut bool
+	closed     bool
+
+	inSeq      int // inSeq is the next expected incoming sequence number, only accessed by the read loop
+	resendFrom int // resendFrom is the start of the pending resend request, or zero
+
+	done chan struct{}
+	err  error
+}
+
+// Dial connects to the given FIX address over TLS and logs the session on
+func Dial(address string, opts *SessionOpts) (*Session, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := tls.Dial("tcp", address, &tls.Config{ServerName: host})
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewSession(conn, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSession logs the session on over the given connection and starts serving it
+func NewSession(conn net.Conn, opts *SessionOpts) (*Session, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.PrivateKey == nil {
+		return nil, fmt.Errorf("private key is missing")
+	}
+	if opts.SenderCompID == "" {
+		return nil, fmt.Errorf("sender comp id is missing")
+	}
+	s := &Session{
+		conn:   conn,
+		r:      bufio.NewReader(conn),
+		opts:   *opts,
+		outSeq: 1,
+		inSeq:  1,
+		done:   make(chan struct{}),
+	}
+	if s.opts.TargetCompID == "" {
+		s.opts.TargetCompID = defaultTargetCompID
+	}
+	if s.opts.HeartBtInt <= 0 {
+		s.opts.HeartBtInt = defaultHeartBtInt
+	}
+	if s.opts.MessageHandling == 0 {
+		s.opts.MessageHandling = MessageHandlingUnordered
+	}
+	if err := s.logon(); err != nil {
+		return nil, err
+	}
+	go s.readLoop()
+	go s.heartbeatLoop()
+	return s, nil
+}
+
+// NewOrder sends in a new order
+func (s *Session) NewOrder(opts *binance.NewOrderOpts) error {
+	m, err := NewOrderSingle(opts)
+	if err != nil {
+		return err
+	}
+	return s.Send(m)
+}
+
+// CancelOrder requests the cancellation of an active order
+func (s *Session) CancelOrder(opts *binance.CancelOrderOpts) error {
+	m, err := OrderCancelRequest(opts)
+	if err != nil {
+		return err
+	}
+	return s.Send(m)
+}
+
+// Send sends the given application message, stamping it with the session header
+func (s *Session) Send(m *Message) error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+	}
+	return s.send(m)
+}
+
+// Logout logs the session out, waiting for the exchange to acknowledge it before closing the connection
+func (s *Session) Logout() error {
+	s.stateMu.Lock()
+	s.loggingOut = true
+	s.stateMu.Unlock()
+	if err := s.Send(NewMessage(MsgTypeLogout)); err != nil {
+		return err
+	}
+	select {
+	case <-s.done:
+	case <-time.After(logoutTimeout):
+	}
+	return s.Close()
+}
+
+// Done returns a channel which is closed once the session terminates
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close closes the connection without logging out
+func (s *Session) Close() error {
+	s.stateMu.Lock()
+	s.closed = true
+	s.stateMu.Unlock()
+	return s.conn.Close()
+}
+
+// logon sends the signed Logon message and waits for the exchange to accept it
+func (s *Session) logon() error {
+	m := NewMessage(MsgTypeLogon).
+		Set(TagEncryptMethod, "0").
+		Set(TagHeartBtInt, strconv.Itoa(s.opts.HeartBtInt)).
+		Set(TagResetSeqNumFlag, "Y").
+		Set(TagUsername, s.opts.APIKey).
+		Set(TagMessageHandling, strconv.Itoa(int(s.opts.MessageHandling)))
+	if err := s.send(m); err != nil {
+		return err
+	}
+	s.conn.SetReadDeadline(time.Now().Add(logonTimeout))
+	defer s.conn.SetReadDeadline(time.Time{})
+	res, err := ReadMessage(s.r)
+	if err != nil {
+		return err
+	}
+	switch res.Type() {
+	case MsgTypeLogon:
+	case MsgTypeLogout, MsgTypeReject:
+		return fmt.Errorf("logon rejected: %s", res.Get(TagText))
+	default:
+		return fmt.Errorf("unexpected logon response: %s", res.Type())
+	}
+	s.inSeq = res.Int(TagMsgSeqNum) + 1
+	s.stateMu.Lock()
+	s.lastRecv = time.Now()
+	s.stateMu.Unlock()
+	return nil
+}
+
+// send stamps the given message with the next outgoing sequence number and writes it
+func (s *Session) send(m *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(m, s.outSeq); err != nil {
+		return err
+	}
+	s.outSeq++
+	return nil
+}
+
+// write stamps the given message with the session header and the given sequence number, and writes it
+// Remark: s.mu must be held
+func (s *Session) write(m *Message, seq int) error {
+	now := time.Now().UTC().Format(sendingTimeFormat)
+	out := NewMessage(m.Type()).
+		Set(TagSenderCompID, s.opts.SenderCompID).
+		Set(TagTargetCompID, s.opts.TargetCompID).
+		Set(TagMsgSeqNum, strconv.Itoa(seq)).
+		Set(TagSendingTime, now)
+	for _, f := range m.Fields {
+		if f.Tag != TagMsgType {
+			out.Fields = append(out.Fields, f)
+		}
+	}
+	// The logon is signed over its header fields, joined by the field delimiter
+	if m.Type() == MsgTypeLogon {
+		payload := strings.Join([]string{string(MsgTypeLogon), s.opts.SenderCompID, s.opts.TargetCompID, strconv.Itoa(seq), now}, string(soh))
+		signature := base64.StdEncoding.EncodeToString(ed25519.Sign(s.opts.PrivateKey, []byte(payload)))
+		out.Set(TagRawDataLength, strconv.Itoa(len(signature))).Set(TagRawData, signature)
+	}
+	if _, err := s.conn.Write(out.Bytes()); err != nil {
+		return err
+	}
+	s.lastSent = time.Now()
+	return nil
+}
+
+// readLoop reads incoming messages, keeping track of their sequence numbers and handling the session messages
+func (s *Session) readLoop() {
+	for {
+		m, err := ReadMessage(s.r)
+		if err != nil {
+			s.terminate(err)
+			return
+		}
+		s.stateMu.Lock()
+		s.lastRecv = time.Now()
+		s.testReqID = ""
+		s.stateMu.Unlock()
+
+		seq := m.Int(TagMsgSeqNum)
+		if m.Type() == MsgTypeSequenceReset {
+			if err := s.handleSequenceReset(m, seq); err != nil {
+				s.fail(err)
+				return
+			}
+			continue
+		}
+		switch {
+		case seq > s.inSeq:
+			// Messages past a gap are dropped until the gap is resent, as the resend covers them as well
+			if s.resendFrom == 0 {
+				s.resendFrom = s.inSeq
+				s.send(NewMessage(MsgTypeResendRequest).
+					Set(TagBeginSeqNo, strconv.Itoa(s.inSeq)).
+					Set(TagEndSeqNo, "0"))
+			}
+			continue
+		case seq < s.inSeq:
+			if m.Get(TagPossDupFlag) == "Y" {
+				continue
+			}
+			s.fail(fmt.Errorf("MsgSeqNum too low, expecting %d but received %d", s.inSeq, seq))
+			return
+		}
+		s.inSeq++
+		if s.resendFrom != 0 && m.Get(TagPossDupFlag) != "Y" {
+			s.resendFrom = 0
+		}
+		if !s.handle(m) {
+			return
+		}
+	}
+}
+
+// handle handles a single in sequence message, returning false once the session has terminated
+func (s *Session) handle(m *Message) bool {
+	switch m.Type() {
+	case MsgTypeHeartbeat:
+	case MsgTypeTestRequest:
+		s.send(NewMessage(MsgTypeHeartbeat).Set(TagTestReqID, m.Get(TagTestReqID)))
+	case MsgTypeResendRequest:
+		s.gapFill(m.Int(TagBeginSeqNo))
+	case MsgTypeReject:
+		s.handleError(fmt.Errorf("message %s rejected: %s", m.Get(TagRefSeqNum), m.Get(TagText)))
+	case MsgTypeLogout:
+		s.stateMu.Lock()
+		loggingOut := s.loggingOut
+		s.stateMu.Unlock()
+		if !loggingOut {
+			s.send(NewMessage(MsgTypeLogout))
+		}
+		s.terminate(fmt.Errorf("logged out: %s", m.Get(TagText)))
+		s.conn.Close()
+		return false
+	case MsgTypeExecutionReport:
+		report, err := ParseExecutionReport(m)
+		if err != nil {
+			s.handleError(err)
+			break
+		}
+		if s.opts.OnExecutionReport != nil {
+			s.opts.OnExecutionReport(report)
+		}
+	default:
+		if s.opts.OnMessage != nil {
+			s.opts.OnMessage(m)
+		}
+	}
+	return true
+}
+
+// handleSequenceReset moves the expected incoming sequence number forward, either for a gap fill or a reset
+func (s *Session) handleSequenceReset(m *Message, seq int) error {
+	if m.Get(TagGapFillFlag) == "Y" && seq > s.inSeq {
+		// The gap fill is itself past a gap, which is left to the pending or a new resend request
+		return nil
+	}
+	newSeq := m.Int(TagNewSeqNo)
+	if newSeq < s.inSeq {
+		return fmt.Errorf("sequence reset to %d is below the expected %d", newSeq, s.inSeq)
+	}
+	s.inSeq = newSeq
+	s.resendFrom = 0
+	return nil
+}
+
+// gapFill answers a resend request by skipping over the requested messages
+// Remark: Sent messages are not stored nor replayed, as resending stale orders is never desired, and the session
+// messages are not to be resent anyway
+func (s *Session) gapFill(begin int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if begin <= 0 || begin >= s.outSeq {
+		return
+	}
+	s.write(NewMessage(MsgTypeSequenceReset).
+		Set(TagPossDupFlag, "Y").
+		Set(TagGapFillFlag, "Y").
+		Set(TagNewSeqNo, strconv.Itoa(s.outSeq)), begin)
+}
+
+// heartbeatLoop sends heartbeats when the session is idle, and test requests when the exchange is
+func (s *Session) heartbeatLoop() {
+	interval := time.Duration(s.opts.HeartBtInt) * time.Second
+	ticker := time.NewTicker(interval / 10)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			idle := now.Sub(s.lastSent) >= interval
+			s.mu.Unlock()
+			if idle {
+				s.send(NewMessage(MsgTypeHeartbeat))
+			}
+
+			s.stateMu.Lock()
+			silent := now.Sub(s.lastRecv)
+			pending := s.testReqID != ""
+			if silent >= interval+interval/5 && !pending {
+				s.testReqID = strconv.FormatInt(now.UnixNano(), 36)
+			}
+			testReqID := s.testReqID
+			s.stateMu.Unlock()
+			if silent >= 2*interval {
+				s.fail(fmt.Errorf("no heartbeat for %v", silent))
+				return
+			}
+			if testReqID != "" && !pending {
+				s.send(NewMessage(MsgTypeTestRequest).Set(TagTestReqID, testReqID))
+			}
+		}
+	}
+}
+
+// fail logs the session out because of the given error, and terminates it
+func (s *Session) fail(err error) {
+	s.send(NewMessage(MsgTypeLogout).Set(TagText, err.Error()))
+	s.conn.Close()
+	s.terminate(err)
+}
+
+func (s *Session) handleError(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
+
+// terminate records the error which terminated the session
+func (s *Session) terminate(err error) {
+	s.stateMu.Lock()
+	select {
+	case <-s.done:
+		s.stateMu.Unlock()
+		return
+	default:
+	}
+	expected := s.closed || s.loggingOut
+	s.err = err
+	if s.closed {
+		s.err = fmt.Errorf("session closed")
+	} else if s.loggingOut {
+		s.err = fmt.Errorf("session logged out")
+	}
+	close(s.done)
+	s.stateMu.Unlock()
+	if !expected {
+		s.handleError(err)
+	}
+}
@@ -0,0 +1,241 @@
+package fix
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/noypi/binance-api"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptor is a local stand-in for the FIX acceptor of the exchange, serving a single session
+type acceptor struct {
+	t      *testing.T
+	conn   net.Conn
+	r      *bufio.Reader
+	outSeq int
+}
+
+// newTestSession logs a session on to a local acceptor, which verifies the logon signature
+func newTestSession(t *testing.T, opts *SessionOpts) (*Session, *acceptor) {
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	opts.APIKey = "key"
+	opts.PrivateKey = private
+	opts.SenderCompID = "TEST"
+
+	client, server := net.Pipe()
+	a := &acceptor{t: t, conn: server, r: bufio.NewReader(server), outSeq: 1}
+	logon := make(chan *Message, 1)
+	go func() {
+		m := a.read()
+		a.send(NewMessage(MsgTypeLogon).Set(TagHeartBtInt, m.Get(TagHeartBtInt)))
+		logon <- m
+	}()
+	s, err := NewSession(client, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	m := <-logon
+	require.Equal(t, MsgTypeLogon, m.Type())
+	require.Equal(t, "TEST", m.Get(TagSenderCompID))
+	require.Equal(t, "SPOT", m.Get(TagTargetCompID))
+	require.Equal(t, "1", m.Get(TagMsgSeqNum))
+	require.Equal(t, "Y", m.Get(TagResetSeqNumFlag))
+	require.Equal(t, "key", m.Get(TagUsername))
+	payload := strings.Join([]string{"A", "TEST", "SPOT", "1", m.Get(TagSendingTime)}, "\x01")
+	signature, err := base64.StdEncoding.DecodeString(m.Get(TagRawData))
+	require.NoError(t, err)
+	require.Equal(t, strconv.Itoa(len(m.Get(TagRawData))), m.Get(TagRawDataLength))
+	require.True(t, ed25519.Verify(public, []byte(payload), signature))
+	return s, a
+}
+
+// read reads the next message sent by the session
+func (a *acceptor) read() *Message {
+	a.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	m, err := ReadMessage(a.r)
+	require.NoError(a.t, err)
+	return m
+}
+
+// send sends the given message to the session with the next sequence number
+func (a *acceptor) send(m *Message) {
+	a.sendSeq(m, a.outSeq)
+	a.outSeq++
+}
+
+func (a *acceptor) sendSeq(m *Message, seq int) {
+	m.Set(TagSenderCompID, "SPOT").Set(TagTargetCompID, "TEST").Set(TagMsgSeqNum, strconv.Itoa(seq))
+	a.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	_, err := a.conn.Write(m.Bytes())
+	require.NoError(a.t, err)
+}
+
+func TestSession_NewOrder(t *testing.T) {
+	reports := make(chan *ExecutionReport, 1)
+	s, a := newTestSession(t, &SessionOpts{OnExecutionReport: func(report *ExecutionReport) { reports <- report }})
+
+	go s.NewOrder(&binance.NewOrderOpts{
+		Symbol:           "ETHBTC",
+		Side:             binance.OrderSideBuy,
+		Type:             binance.OrderTypeLimit,
+		TimeInForce:      binance.TimeInForceGTC,
+		Quantity:         "1.5",
+		Price:            "0.05",
+		NewClientOrderId: "order1",
+	})
+	m := a.read()
+	require.Equal(t, MsgTypeNewOrderSingle, m.Type())
+	require.Equal(t, "2", m.Get(TagMsgSeqNum))
+	require.Equal(t, "order1", m.Get(TagClOrdID))
+	require.Equal(t, "ETHBTC", m.Get(TagSymbol))
+	require.Equal(t, "1", m.Get(TagSide))
+	require.Equal(t, "2", m.Get(TagOrdType))
+	require.Equal(t, "1", m.Get(TagTimeInForce))
+	require.Equal(t, "1.5", m.Get(TagOrderQty))
+	require.Equal(t, "0.05", m.Get(TagPrice))
+	require.False(t, m.Has(TagTriggerPrice))
+
+	a.send(NewMessage(MsgTypeExecutionReport).
+		Set(TagClOrdID, "order1").
+		Set(TagOrderID, "42").
+		Set(TagSymbol, "ETHBTC").
+		Set(TagSide, "1").
+		Set(TagOrdType, "2").
+		Set(TagTimeInForce, "1").
+		Set(TagOrderQty, "1.5").
+		Set(TagPrice, "0.05").
+		Set(TagExecType, "F").
+		Set(TagOrdStatus, "1").
+		Set(TagCumQty, "0.5").
+		Set(TagCumQuoteQty, "0.025").
+		Set(TagLastQty, "0.5").
+		Set(TagLastPx, "0.05").
+		Set(TagLeavesQty, "1").
+		Set(TagTransactTime, "20240102-03:04:05.123456"))
+	report := <-reports
+	require.Equal(t, &binance.QueryOrder{
+		Symbol:             "ETHBTC",
+		OrderID:            42,
+		OrderListID:        -1,
+		ClientOrderID:      "order1",
+		Price:              "0.05",
+		OrigQty:            "1.5",
+		ExecutedQty:        "0.5",
+		CumulativeQuoteQty: "0.025",
+		Status:             binance.OrderStatusPartial,
+		TimeInForce:        binance.TimeInForceGTC,
+		Type:               binance.OrderTypeLimit,
+		Side:               binance.OrderSideBuy,
+		UpdateTime:         1704164645123,
+	}, report.Order)
+	require.Equal(t, binance.OrderStatusTrade, report.ExecutionType)
+	require.Equal(t, "0.5", report.LastQty)
+	require.Equal(t, "0.05", report.LastPrice)
+	require.Equal(t, "1", report.LeavesQty)
+}
+
+func TestSession_CancelOrder(t *testing.T) {
+	s, a := newTestSession(t, &SessionOpts{})
+
+	require.Error(t, s.CancelOrder(&binance.CancelOrderOpts{Symbol: "ETHBTC"}))
+	go s.CancelOrder(&binance.CancelOrderOpts{Symbol: "ETHBTC", OrigClientOrderId: "order1", NewClientOrderId: "cancel1"})
+	m := a.read()
+	require.Equal(t, MsgTypeOrderCancelRequest, m.Type())
+	require.Equal(t, "2", m.Get(TagMsgSeqNum))
+	require.Equal(t, "cancel1", m.Get(TagClOrdID))
+	require.Equal(t, "order1", m.Get(TagOrigClOrdID))
+	require.False(t, m.Has(TagOrderID))
+}
+
+func TestSession_TestRequest(t *testing.T) {
+	_, a := newTestSession(t, &SessionOpts{})
+
+	a.send(NewMessage(MsgTypeTestRequest).Set(TagTestReqID, "ping"))
+	m := a.read()
+	require.Equal(t, MsgTypeHeartbeat, m.Type())
+	require.Equal(t, "ping", m.Get(TagTestReqID))
+}
+
+func TestSession_Heartbeat(t *testing.T) {
+	_, a := newTestSession(t, &SessionOpts{HeartBtInt: 1})
+
+	start := time.Now()
+	m := a.read()
+	require.Equal(t, MsgTypeHeartbeat, m.Type())
+	require.Equal(t, "2", m.Get(TagMsgSeqNum))
+	require.True(t, time.Since(start) >= 800*time.Millisecond)
+
+	// Without incoming messages the session probes the acceptor
+	for m.Type() == MsgTypeHeartbeat {
+		m = a.read()
+	}
+	require.Equal(t, MsgTypeTestRequest, m.Type())
+	require.NotEmpty(t, m.Get(TagTestReqID))
+}
+
+func TestSession_Resend(t *testing.T) {
+	reports := make(chan *ExecutionReport, 3)
+	s, a := newTestSession(t, &SessionOpts{OnExecutionReport: func(report *ExecutionReport) { reports <- report }})
+
+	// A gap in the incoming messages is requested to be resent, and the messages past it are dropped
+	a.sendSeq(NewMessage(MsgTypeExecutionReport).Set(TagOrderID, "3"), 3)
+	m := a.read()
+	require.Equal(t, MsgTypeResendRequest, m.Type())
+	require.Equal(t, "2", m.Get(TagBeginSeqNo))
+	require.Equal(t, "0", m.Get(TagEndSeqNo))
+	a.sendSeq(NewMessage(MsgTypeExecutionReport).Set(TagOrderID, "4"), 4)
+
+	a.sendSeq(NewMessage(MsgTypeSequenceReset).Set(TagGapFillFlag, "Y").Set(TagNewSeqNo, "3").Set(TagPossDupFlag, "Y"), 2)
+	a.sendSeq(NewMessage(MsgTypeExecutionReport).Set(TagOrderID, "3").Set(TagPossDupFlag, "Y"), 3)
+	a.sendSeq(NewMessage(MsgTypeExecutionReport).Set(TagOrderID, "4").Set(TagPossDupFlag, "Y"), 4)
+	a.sendSeq(NewMessage(MsgTypeExecutionReport).Set(TagOrderID, "4").Set(TagPossDupFlag, "Y"), 4)
+	a.outSeq = 5
+	a.send(NewMessage(MsgTypeExecutionReport).Set(TagOrderID, "5"))
+	for _, id := range []int{3, 4, 5} {
+		require.Equal(t, id, (<-reports).Order.OrderID)
+	}
+
+	// Resend requests of the acceptor are gap filled rather than replayed
+	go s.NewOrder(&binance.NewOrderOpts{Symbol: "ETHBTC", Side: binance.OrderSideSell, Type: binance.OrderTypeMarket, Quantity: "1"})
+	require.Equal(t, MsgTypeNewOrderSingle, a.read().Type())
+	a.send(NewMessage(MsgTypeResendRequest).Set(TagBeginSeqNo, "2").Set(TagEndSeqNo, "0"))
+	m = a.read()
+	require.Equal(t, MsgTypeSequenceReset, m.Type())
+	require.Equal(t, "2", m.Get(TagMsgSeqNum))
+	require.Equal(t, "Y", m.Get(TagGapFillFlag))
+	require.Equal(t, "Y", m.Get(TagPossDupFlag))
+	require.Equal(t, "4", m.Get(TagNewSeqNo))
+}
+
+func TestSession_Logout(t *testing.T) {
+	s, a := newTestSession(t, &SessionOpts{})
+
+	logout := make(chan error, 1)
+	go func() { logout <- s.Logout() }()
+	require.Equal(t, MsgTypeLogout, a.read().Type())
+	a.send(NewMessage(MsgTypeLogout))
+	<-logout
+	<-s.Done()
+	require.Error(t, s.NewOrder(&binance.NewOrderOpts{Symbol: "ETHBTC", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1"}))
+}
+
+func TestSession_LogonRejected(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	client, server := net.Pipe()
+	a := &acceptor{t: t, conn: server, r: bufio.NewReader(server), outSeq: 1}
+	go func() {
+		a.read()
+		a.send(NewMessage(MsgTypeLogout).Set(TagText, "invalid signature"))
+	}()
+	_, err = NewSession(client, &SessionOpts{APIKey: "key", PrivateKey: private, SenderCompID: "TEST"})
+	require.EqualError(t, err, "logon rejected: invalid signature")
+}
@@ -0,0 +1,419 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// FuturesClient is a client of the USD-M futures API
+type FuturesClient struct {
+	client *client
+	dialer *websocket.Dialer
+	wsOpts WSOpts
+}
+
+func NewFuturesClient(apikey, secret string) *FuturesClient {
+	return &FuturesClient{
+		client: &client{
+			baseURL: fapiURL,
+			window:  5000,
+			apikey:  apikey,
+			secret:  secret,
+			client:  http.DefaultClient,
+		},
+		dialer: websocket.DefaultDialer,
+	}
+}
+
+func (f *FuturesClient) SetHTTPClient(client *http.Client) {
+	f.client.client = client
+}
+
+// SetMaxResponseSize sets the maximal size in bytes of the responses read, beyond which requests fail
+// Remark: A size of zero or less restores the default of 64MiB
+func (f *FuturesClient) SetMaxResponseSize(size int64) {
+	f.client.maxResponseSize = size
+}
+
+// SetWSOpts sets the keepalive and staleness detection options of websockets opened from now on
+func (f *FuturesClient) SetWSOpts(opts *WSOpts) {
+	f.wsOpts = WSOpts{}
+	if opts != nil {
+		f.wsOpts = *opts
+	}
+}
+
+// Market Data endpoints
+
+// ExchangeInfo get the futures trading rules and symbols information
+func (f *FuturesClient) ExchangeInfo() (*FuturesExchangeInfo, error) {
+	resp := &FuturesExchangeInfo{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/exchangeInfo", nil, false, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarkPrice get the mark price and funding rate of a symbol
+func (f *FuturesClient) MarkPrice(opts *MarkPriceOpts) (*MarkPrice, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" {
+		return nil, fmt.Errorf("symbol is missing")
+	}
+	resp := &MarkPrice{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/premiumIndex", opts, false, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AllMarkPrices get the mark prices and funding rates of all symbols
+func (f *FuturesClient) AllMarkPrices() ([]*MarkPrice, error) {
+	resp := []*MarkPrice{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/premiumIndex", nil, false, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FundingRates get the funding rate history
+func (f *FuturesClient) FundingRates(opts *FundingRateOpts) ([]*FundingRate, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*FundingRate{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/fundingRate", opts, false, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Klines returns kline/candlestick bars for a futures symbol. Klines are uniquely identified by their open time
+func (f *FuturesClient) Klines(opts *KlinesOpts) ([]*Klines, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" || opts.Interval == "" {
+		return nil, fmt.Errorf("symbol or interval are missing")
+	}
+	if opts.Limit == 0 {
+		opts.Limit = 500
+	}
+	if opts.Limit > 1500 {
+		opts.Limit = 1500
+	}
+	klines := []*Klines{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/klines", opts, false, false, &klines); err != nil {
+		return nil, err
+	}
+	return klines, nil
+}
+
+// Signed endpoints, associated with an account
+
+// Positions get the account positions
+func (f *FuturesClient) Positions(opts *FuturesPositionOpts) ([]*FuturesPosition, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*FuturesPosition{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v2/positionRisk", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ChangeLeverage changes the initial leverage of a symbol
+func (f *FuturesClient) ChangeLeverage(opts *LeverageOpts) (*Leverage, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" || opts.Leverage <= 0 {
+		return nil, fmt.Errorf("symbol or leverage are missing")
+	}
+	resp := &Leverage{}
+	if err := f.client.doJSON(http.MethodPost, "fapi/v1/leverage", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ChangeMarginType changes the margin mode of a symbol
+// Remark: The margin mode can't be changed while the symbol has open orders or positions
+func (f *FuturesClient) ChangeMarginType(opts *MarginTypeOpts) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+	if opts.Symbol == "" || opts.MarginType == "" {
+		return fmt.Errorf("symbol or margin type are missing")
+	}
+	_, err := f.client.do(http.MethodPost, "fapi/v1/marginType", opts, true, false)
+	return err
+}
+
+// NewOrder sends in a new futures order
+func (f *FuturesClient) NewOrder(opts *FuturesNewOrderOpts) (*FuturesOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.ClosePosition && (opts.Quantity != "" || opts.ReduceOnly) {
+		return nil, fmt.Errorf("close position can't be used with quantity or reduce only")
+	}
+	resp := &FuturesOrder{}
+	if err := f.client.doJSON(http.MethodPost, "fapi/v1/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// QueryOrder checks a futures order's status
+func (f *FuturesClient) QueryOrder(opts *FuturesQueryOrderOpts) (*FuturesOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	resp := &FuturesOrder{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CancelOrder cancel an active futures order
+func (f *FuturesClient) CancelOrder(opts *FuturesQueryOrderOpts) (*FuturesOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	resp := &FuturesOrder{}
+	if err := f.client.doJSON(http.MethodDelete, "fapi/v1/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// OpenOrders get all open futures orders
+func (f *FuturesClient) OpenOrders(opts *FuturesOpenOrdersOpts) ([]*FuturesOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*FuturesOrder{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v1/openOrders", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Balances get the account futures balances
+func (f *FuturesClient) Balances() ([]*FuturesBalance, error) {
+	resp := []*FuturesBalance{}
+	if err := f.client.doJSON(http.MethodGet, "fapi/v2/balance", nil, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// User stream endpoint
+
+// DataStream starts a new futures user datastream
+// Remark: If the account has an active datastream key, it is returned and its validity is extended
+func (f *FuturesClient) DataStream() (string, error) {
+	resp := &Datastream{}
+	if err := f.client.doJSON(http.MethodPost, "fapi/v1/listenKey", nil, false, true, resp); err != nil {
+		return "", err
+	}
+	return resp.ListenKey, nil
+}
+
+// DataStreamKeepAlive pings the futures datastream key to prevent timeout
+func (f *FuturesClient) DataStreamKeepAlive() error {
+	_, err := f.client.do(http.MethodPut, "fapi/v1/listenKey", nil, false, true)
+	return err
+}
+
+// DataStreamClose closes the futures datastream key
+func (f *FuturesClient) DataStreamClose() error {
+	_, err := f.client.do(http.MethodDelete, "fapi/v1/listenKey", nil, false, true)
+	return err
+}
+
+// dial opens a websocket to the given address, carrying the given stream
+// Remark: Staleness detection is disabled for an empty stream name
+func (f *FuturesClient) dial(addr, stream string) (*wsWrapper, error) {
+	return f.client.dialWS(f.dialer, f.wsOpts, addr, stream)
+}
+
+// dialStream opens a websocket to the given stream
+func (f *FuturesClient) dialStream(stream string) (*wsWrapper, error) {
+	return f.dial(fapiWSAddress+stream, stream)
+}
+
+// DepthWS opens websocket with depth updates for the given futures symbol
+func (f *FuturesClient) DepthWS(symbol string) (*DepthWS, error) {
+	ws, err := f.dialStream(DepthStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &DepthWS{ws}, nil
+}
+
+// KlinesWS opens websocket with klines updates for the given futures symbol with the given interval
+func (f *FuturesClient) KlinesWS(symbol string, interval KlineInterval) (*KlinesWS, error) {
+	ws, err := f.dialStream(KlinesStream(symbol, interval))
+	if err != nil {
+		return nil, err
+	}
+	return &KlinesWS{ws}, nil
+}
+
+// TradesWS opens websocket with aggregated trades updates for the given futures symbol
+func (f *FuturesClient) TradesWS(symbol string) (*TradesWS, error) {
+	ws, err := f.dialStream(TradesStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &TradesWS{ws}, nil
+}
+
+// BookTickerWS opens websocket with best bid and ask updates for the given futures symbol
+func (f *FuturesClient) BookTickerWS(symbol string) (*BookTickerWS, error) {
+	ws, err := f.dialStream(BookTickerStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &BookTickerWS{ws}, nil
+}
+
+// MarkPriceWS opens websocket with mark price and funding rate updates for the given futures symbol
+func (f *FuturesClient) MarkPriceWS(symbol string, speed UpdateSpeed) (*MarkPriceWS, error) {
+	ws, err := f.dialStream(MarkPriceStream(symbol, speed))
+	if err != nil {
+		return nil, err
+	}
+	return &MarkPriceWS{ws}, nil
+}
+
+// AllMarkPricesWS opens websocket with mark price and funding rate updates for all futures symbols
+func (f *FuturesClient) AllMarkPricesWS(speed UpdateSpeed) (*AllMarkPricesWS, error) {
+	ws, err := f.dialStream(AllMarkPricesStream(speed))
+	if err != nil {
+		return nil, err
+	}
+	return &AllMarkPricesWS{ws}, nil
+}
+
+// LiquidationWS opens websocket with liquidation orders for the given futures symbol
+func (f *FuturesClient) LiquidationWS(symbol string) (*LiquidationWS, error) {
+	ws, err := f.dialStream(LiquidationStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &LiquidationWS{ws}, nil
+}
+
+// AllLiquidationsWS opens websocket with liquidation orders for all futures symbols
+func (f *FuturesClient) AllLiquidationsWS() (*LiquidationWS, error) {
+	ws, err := f.dialStream(AllLiquidationsStream())
+	if err != nil {
+		return nil, err
+	}
+	return &LiquidationWS{ws}, nil
+}
+
+// ContinuousKlinesWS opens websocket with klines updates for the given contract type of the given pair, with the given interval
+func (f *FuturesClient) ContinuousKlinesWS(pair string, contractType ContractType, interval KlineInterval) (*ContinuousKlinesWS, error) {
+	ws, err := f.dialStream(ContinuousKlinesStream(pair, contractType, interval))
+	if err != nil {
+		return nil, err
+	}
+	return &ContinuousKlinesWS{ws}, nil
+}
+
+// IndexPriceKlinesWS opens websocket with index price klines updates for the given pair with the given interval
+func (f *FuturesClient) IndexPriceKlinesWS(pair string, interval KlineInterval) (*IndexPriceKlinesWS, error) {
+	ws, err := f.dialStream(IndexPriceKlinesStream(pair, interval))
+	if err != nil {
+		return nil, err
+	}
+	return &IndexPriceKlinesWS{ws}, nil
+}
+
+// CompositeIndexWS opens websocket with composite index updates for the given futures index symbol
+func (f *FuturesClient) CompositeIndexWS(symbol string) (*CompositeIndexWS, error) {
+	ws, err := f.dialStream(CompositeIndexStream(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return &CompositeIndexWS{ws}, nil
+}
+
+// CombinedWS opens a single websocket carrying updates of all the given futures streams
+func (f *FuturesClient) CombinedWS(streams ...string) (*CombinedWS, error) {
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("at least one stream must be given")
+	}
+	stream := strings.Join(streams, "/")
+	ws, err := f.dial(fapiWSStreamAddress+"?streams="+stream, stream)
+	if err != nil {
+		return nil, err
+	}
+	return &CombinedWS{ws}, nil
+}
+
+// UserDataWS opens websocket with futures order and account updates
+func (f *FuturesClient) UserDataWS(listenKey string) (*FuturesUserDataWS, error) {
+	ws, err := f.dial(fapiWSAddress+listenKey, "")
+	if err != nil {
+		return nil, err
+	}
+	return &FuturesUserDataWS{ws}, nil
+}
+
+// FuturesUserDataWS is a wrapper for futures user data websocket
+type FuturesUserDataWS struct {
+	*wsWrapper
+}
+
+// Read reads a futures user data update message from the futures user data websocket
+func (d *FuturesUserDataWS) Read() (FuturesUserDataEvent, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeFuturesUserDataEvent(data)
+}
+
+// DecodeFuturesUserDataEvent decodes the given futures user data message according to its event type
+// Remark: Messages of unsupported event types, e.g. MARGIN_CALL, are returned as *UnknownUserUpdate rather than failing
+func DecodeFuturesUserDataEvent(data []byte) (FuturesUserDataEvent, error) {
+	msgType := &struct {
+		EventType UpdateType `json:"e"` // EventType represents the update type
+		Time      uint64     `json:"E"` // Time represents the event time
+	}{}
+	if err := json.Unmarshal(data, msgType); err != nil {
+		return nil, err
+	}
+	var event FuturesUserDataEvent
+	switch msgType.EventType {
+	case UpdateTypeOrderTradeUpdate:
+		event = &FuturesOrderUpdate{}
+	case UpdateTypeFuturesAccountUpdate:
+		event = &FuturesAccountUpdate{}
+	case UpdateTypeListenKeyExpired:
+		event = &ListenKeyExpiredUpdate{}
+	default:
+		// The data is copied, as it may be a read buffer which is reused
+		return &UnknownUserUpdate{EventType: msgType.EventType, Time: msgType.Time, Data: append([]byte(nil), data...)}, nil
+	}
+	return event, json.Unmarshal(data, event)
+}
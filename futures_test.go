@@ -0,0 +1,67 @@
+package binance
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+// newTestFuturesClient returns a futures client which requests are served by the given handler
+func newTestFuturesClient(t *testing.T, handler http.HandlerFunc) *FuturesClient {
+	client := NewFuturesClient("key", "secret")
+	client.SetHTTPClient(newTestHTTPClient(t, handler))
+	return client
+}
+
+func TestFuturesNewOrder(t *testing.T) {
+	client := newTestFuturesClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/fapi/v1/order", r.URL.Path)
+		require.Equal(t, "key", r.Header.Get("X-MBX-APIKEY"))
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "true", r.PostForm.Get("reduceOnly"))
+		require.Empty(t, r.PostForm.Get("closePosition"))
+		require.NotEmpty(t, r.PostForm.Get("signature"))
+		w.Write([]byte(`{"symbol":"BTCUSDT","orderId":22542179,"clientOrderId":"testOrder","status":"NEW","type":"MARKET",
+			"origType":"MARKET","side":"SELL","positionSide":"BOTH","reduceOnly":true,"closePosition":false,"workingType":"CONTRACT_PRICE"}`))
+	})
+	_, err := client.NewOrder(&FuturesNewOrderOpts{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesOrderTypeStopMarket, StopPrice: "9300", ClosePosition: true, Quantity: "1"})
+	require.Error(t, err)
+
+	order, err := client.NewOrder(&FuturesNewOrderOpts{Symbol: "BTCUSDT", Side: OrderSideSell, Type: FuturesOrderTypeMarket, Quantity: "0.01", ReduceOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(22542179), order.OrderID)
+	require.True(t, order.ReduceOnly)
+	require.Equal(t, PositionSideBoth, order.PositionSide)
+}
+
+func TestDecodeFuturesUserDataEvent(t *testing.T) {
+	event, err := DecodeFuturesUserDataEvent([]byte(`{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{
+		"s":"BTCUSDT","c":"TEST","S":"SELL","o":"TRAILING_STOP_MARKET","f":"GTC","q":"0.001","p":"0","ap":"0","sp":"7103.04",
+		"x":"NEW","X":"NEW","i":8886774,"l":"0","z":"0","L":"0","N":"USDT","n":"0","T":1568879465650,"t":0,"b":"0","a":"9.91",
+		"m":false,"R":false,"wt":"CONTRACT_PRICE","ot":"TRAILING_STOP_MARKET","ps":"LONG","cp":false,"AP":"7476.89","cr":"5.0",
+		"pP":false,"si":0,"ss":0,"rp":"0","V":"EXPIRE_TAKER","pm":"OPPONENT","gtd":0}}`))
+	require.NoError(t, err)
+	order, ok := event.(*FuturesOrderUpdate)
+	require.True(t, ok)
+	require.Equal(t, OrderSideSell, order.Order.Side)
+	require.Equal(t, "BTCUSDT", order.Order.Symbol)
+	require.Equal(t, "0", order.Order.AvgPrice)
+	require.Equal(t, "7476.89", order.Order.ActivationPrice)
+	require.Equal(t, OrderStatusNew, order.Order.Status)
+	require.Equal(t, "USDT", order.Order.CommissionAsset)
+	require.Equal(t, PositionSideLong, order.Order.PositionSide)
+
+	event, err = DecodeFuturesUserDataEvent([]byte(`{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ORDER",
+		"B":[{"a":"USDT","wb":"122624.12345678","cw":"100.12345678","bc":"50.12345678"}],
+		"P":[{"s":"BTCUSDT","pa":"0","ep":"0.00000","bep":"0","cr":"200","up":"0","mt":"isolated","iw":"0.00000000","ps":"BOTH"}]}}`))
+	require.NoError(t, err)
+	account, ok := event.(*FuturesAccountUpdate)
+	require.True(t, ok)
+	require.Equal(t, "ORDER", account.Account.Reason)
+	require.Equal(t, "USDT", account.Account.Balances[0].Asset)
+	require.Equal(t, "isolated", account.Account.Positions[0].MarginType)
+
+	event, err = DecodeFuturesUserDataEvent([]byte(`{"e":"MARGIN_CALL","E":1587727187525}`))
+	require.NoError(t, err)
+	require.Equal(t, UpdateType("MARGIN_CALL"), event.(*UnknownUserUpdate).EventType)
+}
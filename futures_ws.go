@@ -0,0 +1,133 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MarkPriceStream returns the stream name of mark price and funding rate updates for the given futures symbol,
+// pushed every 3 seconds or at the given update speed
+func MarkPriceStream(symbol string, speed UpdateSpeed) string {
+	return withUpdateSpeed(strings.ToLower(symbol)+"@markPrice", speed)
+}
+
+// AllMarkPricesStream returns the stream name of mark price and funding rate updates for all futures symbols
+func AllMarkPricesStream(speed UpdateSpeed) string {
+	return withUpdateSpeed("!markPrice@arr", speed)
+}
+
+// LiquidationStream returns the stream name of liquidation orders for the given futures symbol
+func LiquidationStream(symbol string) string {
+	return strings.ToLower(symbol) + "@forceOrder"
+}
+
+// AllLiquidationsStream returns the stream name of liquidation orders for all futures symbols
+func AllLiquidationsStream() string {
+	return "!forceOrder@arr"
+}
+
+// ContinuousKlinesStream returns the stream name of klines updates for the given contract type of the given pair, with the given interval
+func ContinuousKlinesStream(pair string, contractType ContractType, interval KlineInterval) string {
+	return fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(pair), strings.ToLower(string(contractType)), interval)
+}
+
+// IndexPriceKlinesStream returns the stream name of index price klines updates for the given pair with the given interval
+func IndexPriceKlinesStream(pair string, interval KlineInterval) string {
+	return fmt.Sprintf("%s@indexPriceKline_%s", strings.ToLower(pair), interval)
+}
+
+// CompositeIndexStream returns the stream name of composite index updates for the given futures index symbol, e.g. DEFIUSDT
+func CompositeIndexStream(symbol string) string {
+	return strings.ToLower(symbol) + "@compositeIndex"
+}
+
+// MarkPriceWS is a wrapper for futures mark price websocket
+type MarkPriceWS struct {
+	*wsWrapper
+}
+
+// Read reads a mark price update message from the mark price websocket
+func (d *MarkPriceWS) Read() (*MarkPriceUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &MarkPriceUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// AllMarkPricesWS is a wrapper for futures all market mark prices websocket
+type AllMarkPricesWS struct {
+	*wsWrapper
+}
+
+// Read reads the mark prices update message of all symbols from the all market mark prices websocket
+func (d *AllMarkPricesWS) Read() ([]*MarkPriceUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	updates := []*MarkPriceUpdate{}
+	return updates, json.Unmarshal(data, &updates)
+}
+
+// LiquidationWS is a wrapper for futures liquidation orders websocket
+type LiquidationWS struct {
+	*wsWrapper
+}
+
+// Read reads a liquidation order update message from the liquidation orders websocket
+func (d *LiquidationWS) Read() (*LiquidationUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &LiquidationUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// ContinuousKlinesWS is a wrapper for futures continuous contract klines websocket
+type ContinuousKlinesWS struct {
+	*wsWrapper
+}
+
+// Read reads a continuous contract klines update message from the continuous contract klines websocket
+func (d *ContinuousKlinesWS) Read() (*ContinuousKlineUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &ContinuousKlineUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// IndexPriceKlinesWS is a wrapper for futures index price klines websocket
+type IndexPriceKlinesWS struct {
+	*wsWrapper
+}
+
+// Read reads an index price klines update message from the index price klines websocket
+func (d *IndexPriceKlinesWS) Read() (*IndexPriceKlineUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &IndexPriceKlineUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// CompositeIndexWS is a wrapper for futures composite index websocket
+type CompositeIndexWS struct {
+	*wsWrapper
+}
+
+// Read reads a composite index update message from the composite index websocket
+func (d *CompositeIndexWS) Read() (*CompositeIndexUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &CompositeIndexUpdate{}
+	return update, json.Unmarshal(data, update)
+}
@@ -0,0 +1,63 @@
+package binance
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStreamType_Futures(t *testing.T) {
+	for stream, kind := range map[string]string{
+		MarkPriceStream("BTCUSDT", UpdateSpeed1000ms):                             "markPrice",
+		MarkPriceStream("BTCUSDT", UpdateSpeed1s):                                 "markPrice",
+		AllMarkPricesStream(UpdateSpeed1000ms):                                    "!markPrice@arr",
+		AllMarkPricesStream(UpdateSpeed1s):                                        "!markPrice@arr",
+		LiquidationStream("BTCUSDT"):                                              "forceOrder",
+		AllLiquidationsStream():                                                   "!forceOrder@arr",
+		ContinuousKlinesStream("BTCUSDT", ContractTypePerpetual, KlineInterval1m): "continuousKline",
+		IndexPriceKlinesStream("BTCUSD", KlineInterval15m):                        "indexPriceKline",
+		CompositeIndexStream("DEFIUSDT"):                                          "compositeIndex",
+	} {
+		require.Equal(t, kind, streamType(stream), stream)
+	}
+	require.Equal(t, "btcusdt_current_quarter@continuousKline_1h", ContinuousKlinesStream("BTCUSDT", ContractTypeCurrentQuarter, KlineInterval1h))
+}
+
+func TestDecodeCombinedUpdate_FuturesStreams(t *testing.T) {
+	u, err := decodeCombinedUpdate([]byte(`{"stream":"btcusdt@markPrice@1s","data":{"e":"markPriceUpdate","E":1562305380000,
+		"s":"BTCUSDT","p":"11794.15000000","i":"11784.62659091","P":"11784.25641265","r":"0.00038167","T":1562306400000}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.MarkPrice)
+	require.Equal(t, "11794.15000000", u.MarkPrice.MarkPrice)
+	require.Equal(t, "11784.25641265", u.MarkPrice.EstimatedSettlePrice)
+	require.Equal(t, uint64(1562306400000), u.MarkPrice.NextFundingTime)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"!markPrice@arr@1s","data":[{"e":"markPriceUpdate","s":"BTCUSDT","p":"1"},{"e":"markPriceUpdate","s":"ETHUSDT","p":"2"}]}`))
+	require.NoError(t, err)
+	require.Len(t, u.AllMarkPrices, 2)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"!forceOrder@arr","data":{"e":"forceOrder","E":1568014460893,"o":{"s":"BTCUSDT",
+		"S":"SELL","o":"LIMIT","f":"IOC","q":"0.014","p":"9910","ap":"9910","X":"FILLED","l":"0.014","z":"0.014","T":1568014460893}}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.Liquidation)
+	require.Equal(t, "BTCUSDT", u.Liquidation.Order.Symbol)
+	require.Equal(t, OrderSideSell, u.Liquidation.Order.Side)
+	require.Equal(t, OrderStatusFilled, u.Liquidation.Order.Status)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"btcusdt_perpetual@continuousKline_1m","data":{"e":"continuous_kline","E":1607443058651,
+		"ps":"BTCUSDT","ct":"PERPETUAL","k":{"t":1607443020000,"T":1607443079999,"i":"1m","o":"18787.00","c":"18804.04","x":false}}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.ContinuousKline)
+	require.Equal(t, ContractTypePerpetual, u.ContinuousKline.ContractType)
+	require.Equal(t, "18804.04", u.ContinuousKline.Kline.ClosePrice)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"btcusd@indexPriceKline_1m","data":{"e":"indexPriceKline","ps":"BTCUSD","k":{"i":"1m","c":"0.1"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, "BTCUSD", u.IndexPriceKline.Pair)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"defiusdt@compositeIndex","data":{"e":"compositeIndex","E":1602310596000,
+		"s":"DEFIUSDT","p":"554.41604065","C":"baseAsset","c":[{"b":"BAL","q":"USDT","w":"1.04884844","W":"0.01457800","i":"24.33521021"}]}}`))
+	require.NoError(t, err)
+	require.Equal(t, "baseAsset", u.CompositeIndex.BaseAsset)
+	require.Equal(t, "0.01457800", u.CompositeIndex.Components[0].WeightPercent)
+	require.Equal(t, "1.04884844", u.CompositeIndex.Components[0].WeightQty)
+}
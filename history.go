@@ -0,0 +1,264 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	historyPageLimit = 1000                                      // historyPageLimit is the maximal number of records of a page
+	historyWindow    = uint64(24 * time.Hour / time.Millisecond) // historyWindow is the longest time range, in milliseconds, a page may be searched in
+)
+
+// historyPager pages through a history of records ordered by ID and time. If a start time is set, the records are
+// searched in consecutive time windows until the first one is found, and following pages continue by record ID
+type historyPager[T any] struct {
+	limit     int
+	startTime uint64 // startTime is the start of the next time window to search, or 0 once paging by ID
+	endTime   uint64 // endTime, if set, is the time of the last record to page through
+	next      int    // next is the ID of the record the next page starts from
+	page      []T
+	done      bool
+	err       error
+
+	byTime func(startTime, endTime uint64) ([]T, error) // byTime fetches the first page of records within the given times
+	byID   func(fromID int) ([]T, error)                // byID fetches the page of records starting from the given ID
+	id     func(record T) int
+	time   func(record T) uint64
+}
+
+// fetchNext fetches the next page of records, and returns false once there are no more records or an error occurred
+func (p *historyPager[T]) fetchNext() bool {
+	p.page = nil
+	if p.err != nil || p.done {
+		return false
+	}
+	var page []T
+	if p.startTime != 0 {
+		if page, p.err = p.searchWindows(); p.err != nil || len(page) == 0 {
+			return false
+		}
+		// The window may hold more records than fetched, and following windows more records still: whether there
+		// are any is only known from the next page by ID
+		p.startTime = 0
+	} else {
+		if page, p.err = p.byID(p.next); p.err != nil {
+			return false
+		}
+		if len(page) < p.limit {
+			p.done = true
+		}
+	}
+	if len(page) > 0 {
+		p.next = p.id(page[len(page)-1]) + 1
+	}
+	if p.endTime != 0 {
+		for i, record := range page {
+			if p.time(record) > p.endTime {
+				page = page[:i]
+				p.done = true
+				break
+			}
+		}
+	}
+	p.page = page
+	return len(page) > 0
+}
+
+// searchWindows fetches the first page of records found within consecutive time windows from the start time on,
+// until the end time or the current time
+func (p *historyPager[T]) searchWindows() ([]T, error) {
+	last := p.endTime
+	if last == 0 {
+		last = uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	}
+	for p.startTime <= last {
+		end := p.startTime + historyWindow - 1
+		if end > last {
+			end = last
+		}
+		page, err := p.byTime(p.startTime, end)
+		if err != nil || len(page) > 0 {
+			return page, err
+		}
+		p.startTime = end + 1
+	}
+	p.done = true
+	return nil, nil
+}
+
+// tradesFromOpts are used to fetch a page of account trades starting from a specific trade ID
+// Remark: Unlike TradesOpts, a zero FromID is sent rather than omitted
+type tradesFromOpts struct {
+	Symbol string `url:"symbol"`
+	FromID int    `url:"fromId"`
+	Limit  int    `url:"limit"`
+}
+
+// TradesIterator pages through the trades history of an account for a symbol, from the oldest trade to the newest
+type TradesIterator struct {
+	client *BinanceClient
+	opts   TradesOpts
+	pager  *historyPager[*Trades]
+	err    error
+}
+
+func newTradesIterator(client *BinanceClient, opts *TradesOpts) *TradesIterator {
+	it := &TradesIterator{client: client}
+	if opts == nil {
+		it.err = fmt.Errorf("opts is nil")
+		return it
+	}
+	it.opts = *opts
+	if it.opts.OrderID != 0 {
+		it.err = fmt.Errorf("order id filter is not supported by the iterator, use Trades instead")
+	}
+	if it.opts.Limit == 0 || it.opts.Limit > historyPageLimit {
+		it.opts.Limit = historyPageLimit
+	}
+	it.pager = &historyPager[*Trades]{
+		limit:     it.opts.Limit,
+		startTime: it.opts.StartTime,
+		endTime:   it.opts.EndTime,
+		next:      it.opts.FromID,
+		byTime:    it.fetchByTime,
+		byID:      it.fetch,
+		id:        func(trade *Trades) int { return trade.ID },
+		time:      func(trade *Trades) uint64 { return trade.Time },
+	}
+	return it
+}
+
+// Next fetches the next page of trades, and returns false once there are no more trades or an error occurred
+// Remark: If StartTime is set, trades are searched from StartTime on in windows of 24 hours, the longest the API
+// serves, until the first trade is found. Following pages continue by trade ID. If EndTime is set, the iteration
+// stops at the last trade executed until EndTime
+func (it *TradesIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	ok := it.pager.fetchNext()
+	it.err = it.pager.err
+	return ok
+}
+
+// Page returns the page of trades fetched by the last call to Next
+func (it *TradesIterator) Page() []*Trades {
+	if it.pager == nil {
+		return nil
+	}
+	return it.pager.page
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *TradesIterator) Err() error {
+	return it.err
+}
+
+func (it *TradesIterator) fetchByTime(startTime, endTime uint64) ([]*Trades, error) {
+	return it.client.Trades(&TradesOpts{
+		Symbol:    it.opts.Symbol,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Limit:     it.opts.Limit,
+	})
+}
+
+func (it *TradesIterator) fetch(fromID int) ([]*Trades, error) {
+	resp := []*Trades{}
+	if err := it.client.client.doJSON(http.MethodGet, "api/v3/myTrades", &tradesFromOpts{
+		Symbol: it.opts.Symbol,
+		FromID: fromID,
+		Limit:  it.opts.Limit,
+	}, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// allOrdersFromOpts are used to fetch a page of account orders starting from a specific order ID
+// Remark: Unlike AllOrdersOpts, a zero OrderID is sent rather than omitted
+type allOrdersFromOpts struct {
+	Symbol  string `url:"symbol"`
+	OrderID int    `url:"orderId"`
+	Limit   int    `url:"limit"`
+}
+
+// AllOrdersIterator pages through the orders history of an account for a symbol, from the oldest order to the newest
+type AllOrdersIterator struct {
+	client *BinanceClient
+	opts   AllOrdersOpts
+	pager  *historyPager[*QueryOrder]
+	err    error
+}
+
+func newAllOrdersIterator(client *BinanceClient, opts *AllOrdersOpts) *AllOrdersIterator {
+	it := &AllOrdersIterator{client: client}
+	if opts == nil {
+		it.err = fmt.Errorf("opts is nil")
+		return it
+	}
+	it.opts = *opts
+	if it.opts.Limit == 0 || it.opts.Limit > historyPageLimit {
+		it.opts.Limit = historyPageLimit
+	}
+	it.pager = &historyPager[*QueryOrder]{
+		limit:     it.opts.Limit,
+		startTime: it.opts.StartTime,
+		endTime:   it.opts.EndTime,
+		next:      it.opts.OrderID,
+		byTime:    it.fetchByTime,
+		byID:      it.fetch,
+		id:        func(order *QueryOrder) int { return order.OrderID },
+		time:      func(order *QueryOrder) uint64 { return order.Time },
+	}
+	return it
+}
+
+// Next fetches the next page of orders, and returns false once there are no more orders or an error occurred
+// Remark: If StartTime is set, orders are searched from StartTime on in windows of 24 hours, the longest the API
+// serves, until the first order is found. Following pages continue by order ID. If EndTime is set, the iteration
+// stops at the last order created until EndTime
+func (it *AllOrdersIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	ok := it.pager.fetchNext()
+	it.err = it.pager.err
+	return ok
+}
+
+// Page returns the page of orders fetched by the last call to Next
+func (it *AllOrdersIterator) Page() []*QueryOrder {
+	if it.pager == nil {
+		return nil
+	}
+	return it.pager.page
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *AllOrdersIterator) Err() error {
+	return it.err
+}
+
+func (it *AllOrdersIterator) fetchByTime(startTime, endTime uint64) ([]*QueryOrder, error) {
+	return it.client.AllOrders(&AllOrdersOpts{
+		Symbol:    it.opts.Symbol,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Limit:     it.opts.Limit,
+	})
+}
+
+func (it *AllOrdersIterator) fetch(fromID int) ([]*QueryOrder, error) {
+	resp := []*QueryOrder{}
+	if err := it.client.client.doJSON(http.MethodGet, "api/v3/allOrders", &allOrdersFromOpts{
+		Symbol:  it.opts.Symbol,
+		OrderID: fromID,
+		Limit:   it.opts.Limit,
+	}, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
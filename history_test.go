@@ -0,0 +1,164 @@
+package binance
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const hour = uint64(time.Hour / time.Millisecond)
+
+// historyIDs returns the IDs of the records a history endpoint serves for the given request, out of the given number
+// of records with sequential IDs from 0, created hourly from the given time. Time ranges are limited to 24 hours the
+// way the exchange does. Requests are logged as the ID or the hour they start from
+func historyIDs(w http.ResponseWriter, r *http.Request, idKey string, count int, from uint64, requests *[]string) ([]int, bool) {
+	query := r.URL.Query()
+	id, _ := strconv.Atoi(query.Get(idKey))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	startTime, _ := strconv.ParseUint(query.Get("startTime"), 10, 64)
+	endTime, _ := strconv.ParseUint(query.Get("endTime"), 10, 64)
+	if startTime == 0 {
+		*requests = append(*requests, query.Get(idKey)+"/")
+		endTime = from + uint64(count)*hour
+	} else {
+		*requests = append(*requests, "/"+strconv.FormatUint(startTime/hour, 10))
+		if endTime == 0 {
+			endTime = startTime + 24*hour
+		}
+		if endTime-startTime > 24*hour {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1127,"msg":"More than 24 hours between startTime and endTime."}`))
+			return nil, false
+		}
+		for id = 0; id < count && from+uint64(id)*hour < startTime; id++ {
+		}
+	}
+	ids := []int{}
+	for ; id < count && len(ids) < limit && from+uint64(id)*hour <= endTime; id++ {
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+// tradesHandler serves the given number of account trades with sequential IDs from 0, executed hourly from the given time
+func tradesHandler(t *testing.T, count int, from uint64, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v3/myTrades", r.URL.Path)
+		ids, ok := historyIDs(w, r, "fromId", count, from, requests)
+		if !ok {
+			return
+		}
+		trades := []*Trades{}
+		for _, id := range ids {
+			trades = append(trades, &Trades{Symbol: "ETHBTC", ID: id, Time: from + uint64(id)*hour})
+		}
+		json.NewEncoder(w).Encode(trades)
+	}
+}
+
+func TestTradesHistory(t *testing.T) {
+	requests := []string{}
+	client := newTestClient(t, tradesHandler(t, 25, 0, &requests))
+	it := client.TradesHistory(&TradesOpts{Symbol: "ETHBTC", Limit: 10})
+	ids := []int{}
+	for it.Next() {
+		for _, trade := range it.Page() {
+			ids = append(ids, trade.ID)
+		}
+	}
+	require.NoError(t, it.Err())
+	require.Len(t, ids, 25)
+	for i, id := range ids {
+		require.Equal(t, i, id)
+	}
+	require.Equal(t, []string{"0/", "10/", "20/"}, requests)
+}
+
+func TestTradesHistory_TimeRange(t *testing.T) {
+	requests := []string{}
+	client := newTestClient(t, tradesHandler(t, 100, 0, &requests))
+	it := client.TradesHistory(&TradesOpts{Symbol: "ETHBTC", StartTime: 5 * hour, EndTime: 17 * hour, Limit: 5})
+	ids := []int{}
+	for it.Next() {
+		for _, trade := range it.Page() {
+			ids = append(ids, trade.ID)
+		}
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}, ids)
+	require.Equal(t, []string{"/5", "10/", "15/"}, requests)
+}
+
+func TestTradesHistory_TimeWindows(t *testing.T) {
+	// The first trade is executed after two quiet days, and the time range spans more than the 24 hours the API serves
+	requests := []string{}
+	client := newTestClient(t, tradesHandler(t, 8, 50*hour, &requests))
+	it := client.TradesHistory(&TradesOpts{Symbol: "ETHBTC", StartTime: hour, EndTime: 100 * hour, Limit: 5})
+	ids := []int{}
+	for it.Next() {
+		for _, trade := range it.Page() {
+			ids = append(ids, trade.ID)
+		}
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, ids)
+	require.Equal(t, []string{"/1", "/25", "/49", "5/"}, requests)
+
+	// Nothing is found in a time range without trades
+	requests = requests[:0]
+	it = client.TradesHistory(&TradesOpts{Symbol: "ETHBTC", StartTime: hour, EndTime: 40 * hour})
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"/1", "/25"}, requests)
+}
+
+// allOrdersHandler serves the given number of account orders with sequential IDs from 0, created hourly from the given time
+func allOrdersHandler(t *testing.T, count int, from uint64, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v3/allOrders", r.URL.Path)
+		ids, ok := historyIDs(w, r, "orderId", count, from, requests)
+		if !ok {
+			return
+		}
+		orders := []*QueryOrder{}
+		for _, id := range ids {
+			orders = append(orders, &QueryOrder{Symbol: "ETHBTC", OrderID: id, Time: from + uint64(id)*hour, CumulativeQuoteQty: "1"})
+		}
+		json.NewEncoder(w).Encode(orders)
+	}
+}
+
+func TestAllOrdersHistory(t *testing.T) {
+	requests := []string{}
+	client := newTestClient(t, allOrdersHandler(t, 12, 0, &requests))
+	it := client.AllOrdersHistory(&AllOrdersOpts{Symbol: "ETHBTC", StartTime: 2 * hour, Limit: 4})
+	ids := []int{}
+	for it.Next() {
+		for _, order := range it.Page() {
+			require.Equal(t, "1", order.CumulativeQuoteQty)
+			ids = append(ids, order.OrderID)
+		}
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, ids)
+	require.Equal(t, []string{"/2", "6/", "10/"}, requests)
+}
+
+func TestAllOrdersHistory_TimeWindows(t *testing.T) {
+	// The first order is created after a quiet day, and the time range spans more than the 24 hours the API serves
+	requests := []string{}
+	client := newTestClient(t, allOrdersHandler(t, 30, 30*hour, &requests))
+	it := client.AllOrdersHistory(&AllOrdersOpts{Symbol: "ETHBTC", StartTime: hour, EndTime: 40 * hour, Limit: 4})
+	ids := []int{}
+	for it.Next() {
+		for _, order := range it.Page() {
+			ids = append(ids, order.OrderID)
+		}
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids)
+	require.Equal(t, []string{"/1", "/25", "4/", "8/"}, requests)
+}
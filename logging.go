@@ -0,0 +1,143 @@
+package binance
+
+import (
+	"context"
+	"log/slog"
+	neturl "net/url"
+	"sort"
+	"strings"
+)
+
+// discardLogger is used by the components which were given no logger
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler discards all records
+// Remark: slog.DiscardHandler is only available from Go 1.24
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// orDiscard returns the given logger, or one discarding all records if it is nil
+func orDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return discardLogger
+	}
+	return logger
+}
+
+// SetLogger sets the logger requests, reconnects, listen key renewals and decode errors are logged to, nil disables
+// logging. API keys, signatures and listen keys are never logged
+// Remark: The logger applies to the websockets opened from now on, and must be set before the client is used
+// concurrently
+func (b *BinanceClient) SetLogger(logger *slog.Logger) {
+	b.client.logger = logger
+}
+
+// SetLogger sets the logger requests, reconnects and decode errors are logged to, nil disables logging. API keys,
+// signatures and listen keys are never logged
+// Remark: The logger applies to the websockets opened from now on, and must be set before the client is used
+// concurrently
+func (f *FuturesClient) SetLogger(logger *slog.Logger) {
+	f.client.logger = logger
+}
+
+// SetLogger sets the logger requests, reconnects and decode errors are logged to, nil disables logging. API keys,
+// signatures and listen keys are never logged
+// Remark: The logger applies to the websockets opened from now on, and must be set before the client is used
+// concurrently
+func (d *DeliveryClient) SetLogger(logger *slog.Logger) {
+	d.client.logger = logger
+}
+
+// logCall logs the outcome of the given call: successful calls at debug level, API errors at warn level and calls
+// which got no response at error level
+func (c *client) logCall(req *Request, resp *Response, err error) {
+	attrs := []interface{}{
+		slog.String("endpoint", req.Endpoint),
+		slog.String("method", req.Method),
+		slog.Bool("signed", req.Signed),
+		slog.String("params", formatParams(req.Params)),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.Status), slog.Duration("latency", resp.Latency))
+		if weight, ok := resp.UsedWeight["1m"]; ok {
+			attrs = append(attrs, slog.Int("used_weight", weight))
+		}
+	}
+	switch e := err.(type) {
+	case nil:
+		c.logger.Debug("binance request", attrs...)
+	case *APIError:
+		c.logger.Warn("binance request failed", append(attrs, slog.Any("error", err))...)
+	case *neturl.Error:
+		// The URL of signed GET requests carries their signature, hence only its query free part is logged
+		c.logger.Error("binance request failed", append(attrs, slog.Any("error", e.Err), slog.String("url", redactURL(e.URL)))...)
+	default:
+		c.logger.Error("binance request failed", append(attrs, slog.Any("error", err))...)
+	}
+}
+
+// redactURL returns the given URL without its query, which parameters are logged redacted
+func redactURL(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return redacted
+	}
+	u.RawQuery = ""
+	u.User = nil
+	return u.String()
+}
+
+// formatParams formats the given parameters as an unescaped query, ordered by key, for redacted values to read as is
+func formatParams(values neturl.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range values[key] {
+			params = append(params, key+"="+value)
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// logDecodeError logs the failure to decode the response of the given endpoint
+func (c *client) logDecodeError(endpoint string, err error) {
+	orDiscard(c.logger).Error("binance response decode failed", slog.String("endpoint", endpoint), slog.Any("error", err))
+}
+
+// observers returns the hooks the websockets opened from now on are observed by, which log their connections if
+// a logger is set
+func (c *client) observers() wsHooks {
+	if c.logger == nil {
+		return c.wsHooks
+	}
+	return append(wsHooks{logHooks(c.logger)}, c.wsHooks...)
+}
+
+// logHooks returns hooks logging the connections of websockets to the given logger
+// Remark: Websockets are identified by their address, which listen keys are redacted from
+func logHooks(logger *slog.Logger) *WSHooks {
+	return &WSHooks{
+		OnConnect: func(addr string, err error) {
+			if err != nil {
+				logger.Warn("binance websocket dial failed", slog.String("addr", addr), slog.Any("error", err))
+				return
+			}
+			logger.Info("binance websocket connected", slog.String("addr", addr))
+		},
+		OnDisconnect: func(addr string, err error) {
+			if err != nil {
+				logger.Warn("binance websocket disconnected", slog.String("addr", addr), slog.Any("error", err))
+				return
+			}
+			logger.Debug("binance websocket closed", slog.String("addr", addr))
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package binance
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// logBuffer collects the records of a text logger, which may be written by several routines
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *logBuffer) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.Write(p)
+}
+
+func (l *logBuffer) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.String()
+}
+
+func newTestLogger() (*slog.Logger, *logBuffer) {
+	buf := &logBuffer{}
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), buf
+}
+
+func TestLogger_Requests(t *testing.T) {
+	client := NewBinanceClient("my-api-key", "secret")
+	client.SetHTTPClient(newTestHTTPClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "5")
+		switch r.URL.Path {
+		case "/api/v3/openOrders":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+		case "/api/v1/ticker/allPrices":
+			w.Write([]byte(`{"not":"an array"}`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	logger, buf := newTestLogger()
+	client.SetLogger(logger)
+
+	_, err := client.AllOrders(&AllOrdersOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	require.NoError(t, client.DataStreamKeepAlive("my-listen-key"))
+	_, err = client.OpenOrders(&OpenOrdersOpts{Symbol: "NOPE"})
+	require.Error(t, err)
+	_, err = client.Prices()
+	require.Error(t, err)
+	_, err = client.AccountStatus()
+	require.Error(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 7)
+	require.Contains(t, lines[0], `level=DEBUG msg="binance request" endpoint=api/v3/allOrders method=GET signed=true params="limit=500&symbol=ETHBTC" status=200`)
+	require.Contains(t, lines[0], "used_weight=5")
+	require.Contains(t, lines[1], `endpoint=api/v1/userDataStream method=PUT signed=false params="listenKey=<redacted>"`)
+	require.Contains(t, lines[2], `level=WARN msg="binance request failed" endpoint=api/v3/openOrders`)
+	require.Contains(t, lines[2], `error="status 400: code -1121: Invalid symbol."`)
+	require.Contains(t, lines[3], `level=DEBUG msg="binance request" endpoint=api/v1/ticker/allPrices`)
+	require.Contains(t, lines[4], `level=ERROR msg="binance response decode failed" endpoint=api/v1/ticker/allPrices`)
+	require.Contains(t, lines[6], `level=ERROR msg="binance response decode failed" endpoint=sapi/v1/account/status`)
+	for _, secret := range []string{"my-api-key", "my-listen-key", "signature"} {
+		require.NotContains(t, buf.String(), secret)
+	}
+
+	// Requests which got no response are logged as errors
+	unreachable, err := neturl.Parse("http://127.0.0.1:1")
+	require.NoError(t, err)
+	client.SetHTTPClient(&http.Client{Transport: &redirectTransport{server: unreachable}})
+	_, err = client.Prices()
+	require.Error(t, err)
+	require.Contains(t, buf.String(), `level=ERROR msg="binance request failed" endpoint=api/v1/ticker/allPrices method=GET signed=false params="" error=`)
+
+	// The URL of signed requests which got no response is logged without its signature
+	_, err = client.AllOrders(&AllOrdersOpts{Symbol: "ETHBTC"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signature=")
+	require.Contains(t, buf.String(), `endpoint=api/v3/allOrders method=GET signed=true params="limit=500&symbol=ETHBTC" error=`)
+	require.Contains(t, buf.String(), `url=http://127.0.0.1:1/api/v3/allOrders`)
+	require.NotContains(t, buf.String(), "signature=")
+}
+
+func TestLogger_Websockets(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, tradesUpdatePayload)
+		conn.Close()
+	}))
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/my-listen-key"
+	logger, buf := newTestLogger()
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	ws := &TradesWS{newWSWrapper(conn, addr, "", websocket.DefaultDialer, WSOpts{Reconnect: true}, wsHooks{logHooks(logger)})}
+	for i := 0; i < 2; i++ {
+		_, err := ws.Read()
+		require.NoError(t, err)
+	}
+	ws.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[0], `level=WARN msg="binance websocket disconnected" addr=`)
+	require.Contains(t, lines[1], `level=INFO msg="binance websocket connected" addr=`)
+	require.Contains(t, lines[2], `level=DEBUG msg="binance websocket closed" addr=`)
+	require.Contains(t, lines[1], "/ws/<redacted>")
+	require.NotContains(t, buf.String(), "my-listen-key")
+}
+
+func TestLogger_UserStream(t *testing.T) {
+	api := &fakeUserStreamAPI{addr: newUserDataServer(t)}
+	logger, buf := newTestLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	u := newUserStream(ctx, api, nil, logger)
+	require.NoError(t, u.connect())
+	u.start()
+
+	<-u.OrderUpdates()
+	<-u.AccountUpdates()
+	cancel()
+	for range u.Errors() {
+	}
+	require.Contains(t, buf.String(), `level=INFO msg="binance user stream listen key expired, reconnecting"`)
+	require.Contains(t, buf.String(), `level=INFO msg="binance user stream reconnected"`)
+	require.NotContains(t, buf.String(), "key1")
+}
+
+func TestLogger_Discard(t *testing.T) {
+	logger := orDiscard(nil).With(slog.String("endpoint", "api/v3/order")).WithGroup("request")
+	require.False(t, logger.Enabled(context.Background(), slog.LevelError))
+	logger.Error("binance request failed")
+}
@@ -0,0 +1,232 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Margin endpoints
+
+// MarginAccount get the cross margin account information
+func (b *BinanceClient) MarginAccount() (*MarginAccount, error) {
+	resp := &MarginAccount{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/margin/account", nil, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// IsolatedMarginAccount get the isolated margin accounts information
+func (b *BinanceClient) IsolatedMarginAccount(opts *IsolatedMarginAccountOpts) (*IsolatedMarginAccount, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &IsolatedMarginAccount{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/margin/isolated/account", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginBorrow borrows an asset into a cross or isolated margin account
+func (b *BinanceClient) MarginBorrow(opts *MarginLoanOpts) (*MarginLoan, error) {
+	return b.marginLoan(opts, MarginLoanTypeBorrow)
+}
+
+// MarginRepay repays a borrowed asset of a cross or isolated margin account
+func (b *BinanceClient) MarginRepay(opts *MarginLoanOpts) (*MarginLoan, error) {
+	return b.marginLoan(opts, MarginLoanTypeRepay)
+}
+
+func (b *BinanceClient) marginLoan(opts *MarginLoanOpts, loanType MarginLoanType) (*MarginLoan, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.IsIsolated == MarginIsolatedTrue && opts.Symbol == "" {
+		return nil, fmt.Errorf("symbol is required for isolated margin")
+	}
+	resp := &MarginLoan{}
+	if err := b.client.doJSON(http.MethodPost, "sapi/v1/margin/borrow-repay", &marginLoanOpts{MarginLoanOpts: *opts, Type: loanType}, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginMaxBorrowable get the maximal amount of an asset that can be borrowed
+func (b *BinanceClient) MarginMaxBorrowable(opts *MarginMaxOpts) (*MarginMaxBorrowable, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &MarginMaxBorrowable{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/margin/maxBorrowable", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginMaxTransferable get the maximal amount of an asset that can be transferred out of a margin account
+func (b *BinanceClient) MarginMaxTransferable(opts *MarginMaxOpts) (*MarginMaxTransferable, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &MarginMaxTransferable{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/margin/maxTransferable", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginNewOrder sends in a new margin order
+func (b *BinanceClient) MarginNewOrder(opts *MarginNewOrderOpts) (*MarginNewOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &MarginNewOrder{}
+	if err := b.client.doJSON(http.MethodPost, "sapi/v1/margin/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginQueryOrder checks a margin order's status
+func (b *BinanceClient) MarginQueryOrder(opts *MarginQueryOrderOpts) (*MarginQueryOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	resp := &MarginQueryOrder{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/margin/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginCancelOrder cancel an active margin order
+func (b *BinanceClient) MarginCancelOrder(opts *MarginCancelOrderOpts) (*MarginCancelOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	resp := &MarginCancelOrder{}
+	if err := b.client.doJSON(http.MethodDelete, "sapi/v1/margin/order", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarginInterestHistory get the interests charged on margin loans
+func (b *BinanceClient) MarginInterestHistory(opts *MarginInterestHistoryOpts) (*MarginInterestHistory, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &MarginInterestHistory{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/margin/interestHistory", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Margin user stream endpoints
+
+// MarginDataStream starts a new cross margin user datastream
+func (b *BinanceClient) MarginDataStream() (string, error) {
+	return b.marginDataStream("sapi/v1/userDataStream", nil)
+}
+
+// MarginDataStreamKeepAlive pings the cross margin datastream key to prevent timeout
+func (b *BinanceClient) MarginDataStreamKeepAlive(listenKey string) error {
+	_, err := b.client.do(http.MethodPut, "sapi/v1/userDataStream", Datastream{ListenKey: listenKey}, false, true)
+	return err
+}
+
+// MarginDataStreamClose closes the cross margin datastream key
+func (b *BinanceClient) MarginDataStreamClose(listenKey string) error {
+	_, err := b.client.do(http.MethodDelete, "sapi/v1/userDataStream", Datastream{ListenKey: listenKey}, false, true)
+	return err
+}
+
+// IsolatedMarginDataStream starts a new isolated margin user datastream for a symbol
+func (b *BinanceClient) IsolatedMarginDataStream(symbol string) (string, error) {
+	return b.marginDataStream("sapi/v1/userDataStream/isolated", &IsolatedDatastream{Symbol: symbol})
+}
+
+// IsolatedMarginDataStreamKeepAlive pings the isolated margin datastream key of a symbol to prevent timeout
+func (b *BinanceClient) IsolatedMarginDataStreamKeepAlive(symbol, listenKey string) error {
+	_, err := b.client.do(http.MethodPut, "sapi/v1/userDataStream/isolated", &IsolatedDatastream{Symbol: symbol, ListenKey: listenKey}, false, true)
+	return err
+}
+
+// IsolatedMarginDataStreamClose closes the isolated margin datastream key of a symbol
+func (b *BinanceClient) IsolatedMarginDataStreamClose(symbol, listenKey string) error {
+	_, err := b.client.do(http.MethodDelete, "sapi/v1/userDataStream/isolated", &IsolatedDatastream{Symbol: symbol, ListenKey: listenKey}, false, true)
+	return err
+}
+
+func (b *BinanceClient) marginDataStream(endpoint string, data interface{}) (string, error) {
+	resp := &Datastream{}
+	if err := b.client.doJSON(http.MethodPost, endpoint, data, false, true, resp); err != nil {
+		return "", err
+	}
+	return resp.ListenKey, nil
+}
+
+// MarginUserStream opens a cross margin user data stream, managing the lifecycle of its datastream key
+// Remark: Updates are delivered until the given context is cancelled
+func (b *BinanceClient) MarginUserStream(ctx context.Context, opts *UserStreamOpts) (*UserStream, error) {
+	u := newUserStream(ctx, &marginUserStreamAPI{client: b}, opts, b.client.logger)
+	if err := u.connect(); err != nil {
+		return nil, err
+	}
+	u.start()
+	return u, nil
+}
+
+// IsolatedMarginUserStream opens an isolated margin user data stream for a symbol, managing the lifecycle of its datastream key
+// Remark: Updates are delivered until the given context is cancelled
+func (b *BinanceClient) IsolatedMarginUserStream(ctx context.Context, symbol string, opts *UserStreamOpts) (*UserStream, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is missing")
+	}
+	u := newUserStream(ctx, &marginUserStreamAPI{client: b, symbol: symbol}, opts, b.client.logger)
+	if err := u.connect(); err != nil {
+		return nil, err
+	}
+	u.start()
+	return u, nil
+}
+
+// marginUserStreamAPI manages margin datastream keys for user streams, of the isolated margin account of symbol if set
+type marginUserStreamAPI struct {
+	client *BinanceClient
+	symbol string
+}
+
+func (m *marginUserStreamAPI) DataStream() (string, error) {
+	if m.symbol != "" {
+		return m.client.IsolatedMarginDataStream(m.symbol)
+	}
+	return m.client.MarginDataStream()
+}
+
+func (m *marginUserStreamAPI) DataStreamKeepAlive(listenKey string) error {
+	if m.symbol != "" {
+		return m.client.IsolatedMarginDataStreamKeepAlive(m.symbol, listenKey)
+	}
+	return m.client.MarginDataStreamKeepAlive(listenKey)
+}
+
+func (m *marginUserStreamAPI) DataStreamClose(listenKey string) error {
+	if m.symbol != "" {
+		return m.client.IsolatedMarginDataStreamClose(m.symbol, listenKey)
+	}
+	return m.client.MarginDataStreamClose(listenKey)
+}
+
+func (m *marginUserStreamAPI) AccountInfoWS(listenKey string) (*AccountInfoWS, error) {
+	return m.client.AccountInfoWS(listenKey)
+}
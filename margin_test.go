@@ -0,0 +1,58 @@
+package binance
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestMarginNewOrder(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sapi/v1/margin/order", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "BTCUSDT", r.PostForm.Get("symbol"))
+		require.Equal(t, "TRUE", r.PostForm.Get("isIsolated"))
+		require.Equal(t, "MARGIN_BUY", r.PostForm.Get("sideEffectType"))
+		require.Equal(t, "false", r.PostForm.Get("autoRepayAtCancel"))
+		w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"6gCrw2kRUAF9CvJDGP16IP","isIsolated":true,"transactTime":1507725176595,"marginBuyBorrowAsset":"USDT","marginBuyBorrowAmount":"10"}`))
+	})
+	autoRepay := false
+	res, err := client.MarginNewOrder(&MarginNewOrderOpts{
+		NewOrderOpts:      NewOrderOpts{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: "0.001"},
+		IsIsolated:        MarginIsolatedTrue,
+		SideEffectType:    SideEffectTypeMarginBuy,
+		AutoRepayAtCancel: &autoRepay,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 28, res.OrderID)
+	require.True(t, res.IsIsolated)
+	require.Equal(t, "10", res.MarginBuyBorrowAmount)
+}
+
+func TestMarginQueryOrder(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sapi/v1/margin/order", r.URL.Path)
+		require.Equal(t, "213205622", r.URL.Query().Get("orderId"))
+		w.Write([]byte(`{"symbol":"ZBNBTC","orderId":213205622,"status":"NEW","side":"SELL","isIsolated":true,"isWorking":true}`))
+	})
+	res, err := client.MarginQueryOrder(&MarginQueryOrderOpts{Symbol: "ZBNBTC", OrderID: 213205622})
+	require.NoError(t, err)
+	require.Equal(t, OrderStatusNew, res.Status)
+	require.True(t, res.IsIsolated)
+	require.True(t, res.Working)
+}
+
+func TestMarginBorrow(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sapi/v1/margin/borrow-repay", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "BORROW", r.PostForm.Get("type"))
+		require.Equal(t, "BTC", r.PostForm.Get("asset"))
+		w.Write([]byte(`{"tranId":100000001}`))
+	})
+	_, err := client.MarginBorrow(&MarginLoanOpts{Asset: "BTC", IsIsolated: MarginIsolatedTrue, Amount: "1"})
+	require.Error(t, err)
+	res, err := client.MarginBorrow(&MarginLoanOpts{Asset: "BTC", Amount: "1"})
+	require.NoError(t, err)
+	require.Equal(t, int64(100000001), res.TranID)
+}
@@ -0,0 +1,194 @@
+// Package metrics collects the metrics of binance clients and exposes them in the Prometheus text format
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/noypi/binance-api"
+)
+
+var (
+	// LatencyBuckets are the upper bounds in seconds of the request latency histogram buckets
+	LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	// LagBuckets are the upper bounds in seconds of the websocket message lag histogram buckets
+	LagBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 5}
+)
+
+// eventTimeKey precedes the event time of websocket messages, from which their lag is measured
+var eventTimeKey = []byte(`"E":`)
+
+// Client is a client which API calls and websockets can be instrumented, e.g. *binance.BinanceClient,
+// *binance.FuturesClient or *binance.DeliveryClient
+type Client interface {
+	Use(middlewares ...binance.Middleware)
+	UseWS(hooks *binance.WSHooks)
+}
+
+// Collector collects the metrics of the clients it instruments
+// Remark: Websockets are labeled by the address they were dialed to, which carries their stream names
+type Collector struct {
+	mu sync.Mutex
+
+	requests   map[[3]string]uint64  // requests are counted by endpoint, method and status
+	latencies  map[string]*histogram // latencies are observed by endpoint
+	errors     map[[2]string]uint64  // errors are counted by endpoint and API error code
+	usedWeight map[string]int        // usedWeight is the latest used request weight by interval
+	orderCount map[string]int        // orderCount is the latest order count by interval
+	wsConnects map[[2]string]uint64  // wsConnects are counted by address and result
+	wsDrops    map[string]uint64     // wsDrops are the connections lost by address, excluding closed ones
+	wsReconns  map[string]uint64     // wsReconns are the successful dials of previously connected addresses
+	wsSeen     map[string]bool       // wsSeen holds the addresses which were connected before
+	wsMessages map[string]uint64     // wsMessages are counted by address
+	wsLags     map[string]*histogram // wsLags are observed by address, for messages carrying an event time
+	now        func() time.Time      // now returns the current time, which message lags are measured at
+}
+
+// New returns a new empty collector
+func New() *Collector {
+	return &Collector{
+		requests:   map[[3]string]uint64{},
+		latencies:  map[string]*histogram{},
+		errors:     map[[2]string]uint64{},
+		usedWeight: map[string]int{},
+		orderCount: map[string]int{},
+		wsConnects: map[[2]string]uint64{},
+		wsDrops:    map[string]uint64{},
+		wsReconns:  map[string]uint64{},
+		wsSeen:     map[string]bool{},
+		wsMessages: map[string]uint64{},
+		wsLags:     map[string]*histogram{},
+		now:        time.Now,
+	}
+}
+
+// Instrument collects the metrics of the API calls and the websockets of the given client
+// Remark: Only websockets opened after instrumenting the client are observed
+func (c *Collector) Instrument(client Client) {
+	client.Use(c.Middleware())
+	client.UseWS(c.WSHooks())
+}
+
+// Middleware returns the middleware which collects the metrics of API calls
+func (c *Collector) Middleware() binance.Middleware {
+	return func(next binance.Handler) binance.Handler {
+		return func(req *binance.Request) (*binance.Response, error) {
+			resp, err := next(req)
+			c.observeCall(req, resp, err)
+			return resp, err
+		}
+	}
+}
+
+// WSHooks returns the hooks which collect the metrics of websockets
+func (c *Collector) WSHooks() *binance.WSHooks {
+	return &binance.WSHooks{
+		OnConnect:    c.observeConnect,
+		OnDisconnect: c.observeDisconnect,
+		OnMessage:    c.observeMessage,
+	}
+}
+
+func (c *Collector) observeCall(req *binance.Request, resp *binance.Response, err error) {
+	status := "error"
+	if resp != nil {
+		status = strconv.Itoa(resp.Status)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests[[3]string{req.Endpoint, req.Method, status}]++
+	if apiErr, ok := err.(*binance.APIError); ok {
+		c.errors[[2]string{req.Endpoint, strconv.Itoa(apiErr.Code)}]++
+	}
+	if resp == nil {
+		return
+	}
+	if resp.Latency > 0 {
+		h := c.latencies[req.Endpoint]
+		if h == nil {
+			h = newHistogram(LatencyBuckets)
+			c.latencies[req.Endpoint] = h
+		}
+		h.observe(resp.Latency.Seconds())
+	}
+	for interval, weight := range resp.UsedWeight {
+		c.usedWeight[interval] = weight
+	}
+	for interval, count := range resp.OrderCount {
+		c.orderCount[interval] = count
+	}
+}
+
+func (c *Collector) observeConnect(addr string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wsConnects[[2]string{addr, result}]++
+	if err != nil {
+		return
+	}
+	if c.wsSeen[addr] {
+		c.wsReconns[addr]++
+	}
+	c.wsSeen[addr] = true
+}
+
+func (c *Collector) observeDisconnect(addr string, err error) {
+	if err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wsDrops[addr]++
+}
+
+func (c *Collector) observeMessage(addr string, data []byte) {
+	eventTime, ok := parseEventTime(data)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wsMessages[addr]++
+	if !ok {
+		return
+	}
+	lag := c.now().Sub(time.Unix(0, eventTime*int64(time.Millisecond))).Seconds()
+	if lag < 0 {
+		// The clocks of the server and the host are never perfectly in sync
+		lag = 0
+	}
+	h := c.wsLags[addr]
+	if h == nil {
+		h = newHistogram(LagBuckets)
+		c.wsLags[addr] = h
+	}
+	h.observe(lag)
+}
+
+// parseEventTime returns the first event time in milliseconds the given message carries, if any
+func parseEventTime(data []byte) (int64, bool) {
+	i := bytes.Index(data, eventTimeKey)
+	if i < 0 {
+		return 0, false
+	}
+	var t int64
+	digits := 0
+	for _, c := range data[i+len(eventTimeKey):] {
+		if c < '0' || c > '9' {
+			break
+		}
+		t = t*10 + int64(c-'0')
+		digits++
+	}
+	return t, digits > 0 && digits < 19
+}
+
+// ServeHTTP serves the collected metrics in the Prometheus text format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	c.WriteTo(w)
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"testing"
+	"time"
+
+	"github.com/noypi/binance-api"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends all requests to the given server rather than to the exchange
+type redirectTransport struct {
+	server *neturl.URL
+}
+
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = r.server.Scheme
+	req.URL.Host = r.server.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// scrape returns the metrics served by the given collector
+func scrape(t *testing.T, c *Collector) string {
+	server := httptest.NewServer(c)
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, contentType, resp.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCollector_Requests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "21")
+		w.Header().Set("X-MBX-ORDER-COUNT-10S", "2")
+		if r.URL.Query().Get("symbol") == "NOPE" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	u, err := neturl.Parse(server.URL)
+	require.NoError(t, err)
+	client := binance.NewBinanceClient("key", "secret")
+	client.SetHTTPClient(&http.Client{Transport: &redirectTransport{server: u}})
+	c := New()
+	c.Instrument(client)
+
+	_, err = client.OpenOrders(&binance.OpenOrdersOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	_, err = client.OpenOrders(&binance.OpenOrdersOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	_, err = client.OpenOrders(&binance.OpenOrdersOpts{Symbol: "NOPE"})
+	require.Error(t, err)
+
+	out := scrape(t, c)
+	require.Contains(t, out, "# TYPE binance_requests_total counter\n"+
+		`binance_requests_total{endpoint="api/v3/openOrders",method="GET",status="200"} 2`+"\n"+
+		`binance_requests_total{endpoint="api/v3/openOrders",method="GET",status="400"} 1`+"\n")
+	require.Contains(t, out, `binance_request_errors_total{endpoint="api/v3/openOrders",code="-1121"} 1`+"\n")
+	require.Contains(t, out, `binance_request_duration_seconds_bucket{endpoint="api/v3/openOrders",le="+Inf"} 3`+"\n")
+	require.Contains(t, out, `binance_request_duration_seconds_count{endpoint="api/v3/openOrders"} 3`+"\n")
+	require.Contains(t, out, "# TYPE binance_used_weight gauge\n"+`binance_used_weight{interval="1m"} 21`+"\n")
+	require.Contains(t, out, `binance_order_count{interval="10s"} 2`+"\n")
+	require.NotContains(t, out, "binance_ws_")
+}
+
+func TestCollector_Websockets(t *testing.T) {
+	c := New()
+	now := time.Unix(1672515782, 136000000)
+	c.now = func() time.Time { return now }
+	hooks := c.WSHooks()
+	const addr = "wss://stream.binance.com:9443/ws/bnbbtc@depth"
+
+	hooks.OnConnect(addr, nil)
+	hooks.OnMessage(addr, []byte(`{"e":"depthUpdate","E":1672515782130,"s":"BNBBTC"}`))
+	hooks.OnMessage(addr, []byte(`{"e":"depthUpdate","E":1672515782036,"s":"BNBBTC"}`))
+	hooks.OnMessage(addr, []byte(`{"result":null,"id":1}`))
+	hooks.OnDisconnect(addr, errors.New("unexpected EOF"))
+	hooks.OnConnect(addr, errors.New("dial failed"))
+	hooks.OnConnect(addr, nil)
+	hooks.OnDisconnect(addr, nil)
+
+	out := scrape(t, c)
+	require.Contains(t, out, `binance_ws_connects_total{addr="`+addr+`",result="failure"} 1`+"\n"+
+		`binance_ws_connects_total{addr="`+addr+`",result="success"} 2`+"\n")
+	require.Contains(t, out, `binance_ws_reconnects_total{addr="`+addr+`"} 1`+"\n")
+	require.Contains(t, out, `binance_ws_disconnects_total{addr="`+addr+`"} 1`+"\n")
+	require.Contains(t, out, `binance_ws_messages_total{addr="`+addr+`"} 3`+"\n")
+	require.Contains(t, out, `binance_ws_message_lag_seconds_bucket{addr="`+addr+`",le="0.005"} 0`+"\n"+
+		`binance_ws_message_lag_seconds_bucket{addr="`+addr+`",le="0.01"} 1`+"\n")
+	require.Contains(t, out, `binance_ws_message_lag_seconds_bucket{addr="`+addr+`",le="0.1"} 2`+"\n")
+	require.Contains(t, out, `binance_ws_message_lag_seconds_count{addr="`+addr+`"} 2`+"\n")
+}
+
+func TestExposition_Escaping(t *testing.T) {
+	e := &exposition{}
+	e.metric("m", "counter", "help", []*sample{{labels: []string{"addr", "a\"b\\c\nd"}, value: 1.5}})
+	require.Equal(t, "# HELP m help\n# TYPE m counter\n"+`m{addr="a\"b\\c\nd"} 1.5`+"\n", e.String())
+
+	// Metrics without samples are omitted
+	require.Empty(t, scrape(t, New()))
+}
+
+func TestParseEventTime(t *testing.T) {
+	eventTime, ok := parseEventTime([]byte(`{"stream":"bnbbtc@aggTrade","data":{"e":"aggTrade","E":1672515782136}}`))
+	require.True(t, ok)
+	require.Equal(t, int64(1672515782136), eventTime)
+	_, ok = parseEventTime([]byte(`{"E":"x"}`))
+	require.False(t, ok)
+}
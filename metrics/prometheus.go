@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// contentType is the content type of the Prometheus text format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// histogram counts observations in buckets of the given upper bounds
+type histogram struct {
+	bounds []float64
+	counts []uint64 // counts are the observations of each bucket, not including the ones of the lower buckets
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	if i := sort.SearchFloat64s(h.bounds, v); i < len(h.bounds) {
+		h.counts[i]++
+	}
+	h.sum += v
+	h.count++
+}
+
+// sample is a single value of a metric, with its labels given as name and value pairs
+type sample struct {
+	labels []string
+	value  float64
+	hist   *histogram
+}
+
+// key orders the samples of a metric by their label values
+func (s *sample) key() string {
+	return strings.Join(s.labels, "\xff")
+}
+
+// WriteTo writes the collected metrics to the given writer in the Prometheus text format
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	e := &exposition{}
+	c.mu.Lock()
+	samples := []*sample{}
+	for k, v := range c.requests {
+		samples = append(samples, &sample{labels: []string{"endpoint", k[0], "method", k[1], "status", k[2]}, value: float64(v)})
+	}
+	e.metric("binance_requests_total", "counter", "API calls by endpoint, method and HTTP status, or error if no response was received", samples)
+
+	samples = []*sample{}
+	for endpoint, h := range c.latencies {
+		samples = append(samples, &sample{labels: []string{"endpoint", endpoint}, hist: h})
+	}
+	e.metric("binance_request_duration_seconds", "histogram", "Latency of API calls by endpoint", samples)
+
+	samples = []*sample{}
+	for k, v := range c.errors {
+		samples = append(samples, &sample{labels: []string{"endpoint", k[0], "code", k[1]}, value: float64(v)})
+	}
+	e.metric("binance_request_errors_total", "counter", "API errors by endpoint and error code", samples)
+
+	samples = []*sample{}
+	for interval, v := range c.usedWeight {
+		samples = append(samples, &sample{labels: []string{"interval", interval}, value: float64(v)})
+	}
+	e.metric("binance_used_weight", "gauge", "Latest reported request weight used within the interval", samples)
+
+	samples = []*sample{}
+	for interval, v := range c.orderCount {
+		samples = append(samples, &sample{labels: []string{"interval", interval}, value: float64(v)})
+	}
+	e.metric("binance_order_count", "gauge", "Latest reported number of orders placed within the interval", samples)
+
+	samples = []*sample{}
+	for k, v := range c.wsConnects {
+		samples = append(samples, &sample{labels: []string{"addr", k[0], "result", k[1]}, value: float64(v)})
+	}
+	e.metric("binance_ws_connects_total", "counter", "Websocket dials by address and result", samples)
+
+	e.counters("binance_ws_reconnects_total", "Successful websocket re-dials of previously connected addresses", c.wsReconns)
+	e.counters("binance_ws_disconnects_total", "Websocket connections lost by address, excluding closed ones", c.wsDrops)
+	e.counters("binance_ws_messages_total", "Websocket messages read by address", c.wsMessages)
+
+	samples = []*sample{}
+	for addr, h := range c.wsLags {
+		samples = append(samples, &sample{labels: []string{"addr", addr}, hist: h})
+	}
+	e.metric("binance_ws_message_lag_seconds", "histogram", "Delay between the event time of websocket messages and their reading, by address", samples)
+	c.mu.Unlock()
+	return e.WriteTo(w)
+}
+
+// exposition builds the Prometheus text format of metrics
+type exposition struct {
+	bytes.Buffer
+}
+
+// counters writes a counter metric of the given counts by address
+func (e *exposition) counters(name, help string, counts map[string]uint64) {
+	samples := make([]*sample, 0, len(counts))
+	for addr, v := range counts {
+		samples = append(samples, &sample{labels: []string{"addr", addr}, value: float64(v)})
+	}
+	e.metric(name, "counter", help, samples)
+}
+
+// metric writes the given samples of a metric, ordered by their labels
+func (e *exposition) metric(name, typ, help string, samples []*sample) {
+	if len(samples) == 0 {
+		return
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].key() < samples[j].key()
+	})
+	e.WriteString("# HELP " + name + " " + help + "\n")
+	e.WriteString("# TYPE " + name + " " + typ + "\n")
+	for _, s := range samples {
+		if s.hist == nil {
+			e.sample(name, s.labels, s.value)
+			continue
+		}
+		cumulative := uint64(0)
+		for i, bound := range s.hist.bounds {
+			cumulative += s.hist.counts[i]
+			e.sample(name+"_bucket", append(s.labels, "le", formatFloat(bound)), float64(cumulative))
+		}
+		e.sample(name+"_bucket", append(s.labels, "le", "+Inf"), float64(s.hist.count))
+		e.sample(name+"_sum", s.labels, s.hist.sum)
+		e.sample(name+"_count", s.labels, float64(s.hist.count))
+	}
+}
+
+func (e *exposition) sample(name string, labels []string, value float64) {
+	e.WriteString(name)
+	for i := 0; i+1 < len(labels); i += 2 {
+		if i == 0 {
+			e.WriteByte('{')
+		} else {
+			e.WriteByte(',')
+		}
+		e.WriteString(labels[i] + `="` + escapeLabel(labels[i+1]) + `"`)
+	}
+	if len(labels) > 0 {
+		e.WriteByte('}')
+	}
+	e.WriteString(" " + formatFloat(value) + "\n")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
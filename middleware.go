@@ -0,0 +1,181 @@
+package binance
+
+import (
+	"bytes"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redacted replaces the values of secret request parameters and listen keys passed to middlewares and hooks
+const redacted = "<redacted>"
+
+// redactedParams are the request parameters which values are redacted
+var redactedParams = map[string]bool{
+	"signature": true,
+	"listenKey": true,
+}
+
+// Request describes an API call passed through the middlewares of a client
+type Request struct {
+	Endpoint string        // Endpoint is the path of the called endpoint, e.g. api/v3/order
+	Method   string        // Method is the HTTP method of the call
+	Signed   bool          // Signed indicates whether the call is signed
+	Params   neturl.Values // Params are the parameters of the call with secrets redacted, excluding the timestamp, window and signature which signed calls are sent with
+
+	values neturl.Values // values are the parameters of the call, as sent
+	stream bool          // stream indicates whether the call is stream related, hence sent with the API key
+	accept string        // accept is the content type the response is requested in
+	buf    *bytes.Buffer // buf is the buffer the response body is read into
+}
+
+// Response describes the outcome of an API call passed through the middlewares of a client
+type Response struct {
+	Status      int            // Status is the HTTP status code of the response
+	ContentType string         // ContentType is the content type of the response
+	Latency     time.Duration  // Latency is the time from sending the request until its response was read
+	UsedWeight  map[string]int // UsedWeight holds the request weight used so far, keyed by the interval it is counted in, e.g. 1m
+	OrderCount  map[string]int // OrderCount holds the number of orders placed so far, keyed by the interval it is counted in, e.g. 10s or 1d
+	Body        []byte         // Body is the read body of the response, which is only valid until the call returns
+}
+
+// Handler performs an API call
+// Remark: Unsuccessful responses are returned along with their error, which is an *APIError if their body carries one
+type Handler func(req *Request) (*Response, error)
+
+// Middleware wraps the handler of the next middleware, or the one performing the actual HTTP request. It may
+// observe or alter the request and its outcome, or respond without calling next at all, e.g. to inject faults
+type Middleware func(next Handler) Handler
+
+// WSHooks observe the websockets opened by a client
+// Remark: Hooks are called from the dialing and reading routines, hence a blocking hook delays the websocket.
+// Websockets are identified by the address they were dialed to, with listen keys redacted
+type WSHooks struct {
+	OnConnect    func(addr string, err error)   // OnConnect, if set, is called after every dial, including re-dials, with its error if it failed
+	OnDisconnect func(addr string, err error)   // OnDisconnect, if set, is called once a connection is lost, with the error it failed with, or nil if it was closed
+	OnMessage    func(addr string, data []byte) // OnMessage, if set, is called with every message read, which must not be retained
+}
+
+// wsHooks calls the hooks of every registered WSHooks in order
+type wsHooks []*WSHooks
+
+func (h wsHooks) connect(addr string, err error) {
+	for _, hooks := range h {
+		if hooks.OnConnect != nil {
+			hooks.OnConnect(addr, err)
+		}
+	}
+}
+
+func (h wsHooks) disconnect(addr string, err error) {
+	for _, hooks := range h {
+		if hooks.OnDisconnect != nil {
+			hooks.OnDisconnect(addr, err)
+		}
+	}
+}
+
+func (h wsHooks) message(addr string, data []byte) {
+	for _, hooks := range h {
+		if hooks.OnMessage != nil {
+			hooks.OnMessage(addr, data)
+		}
+	}
+}
+
+// Use appends the given middlewares to the chain every API call of the client passes through, the first of which
+// sees the calls first
+// Remark: Middlewares must be added before the client is used concurrently
+func (b *BinanceClient) Use(middlewares ...Middleware) {
+	b.client.use(middlewares...)
+}
+
+// UseWS registers the given hooks for the websockets opened by the client from now on
+// Remark: Hooks must be registered before the client is used concurrently
+func (b *BinanceClient) UseWS(hooks *WSHooks) {
+	if hooks != nil {
+		b.client.wsHooks = append(b.client.wsHooks, hooks)
+	}
+}
+
+// Use appends the given middlewares to the chain every API call of the client passes through, the first of which
+// sees the calls first
+// Remark: Middlewares must be added before the client is used concurrently
+func (f *FuturesClient) Use(middlewares ...Middleware) {
+	f.client.use(middlewares...)
+}
+
+// UseWS registers the given hooks for the websockets opened by the client from now on
+// Remark: Hooks must be registered before the client is used concurrently
+func (f *FuturesClient) UseWS(hooks *WSHooks) {
+	if hooks != nil {
+		f.client.wsHooks = append(f.client.wsHooks, hooks)
+	}
+}
+
+// Use appends the given middlewares to the chain every API call of the client passes through, the first of which
+// sees the calls first
+// Remark: Middlewares must be added before the client is used concurrently
+func (d *DeliveryClient) Use(middlewares ...Middleware) {
+	d.client.use(middlewares...)
+}
+
+// UseWS registers the given hooks for the websockets opened by the client from now on
+// Remark: Hooks must be registered before the client is used concurrently
+func (d *DeliveryClient) UseWS(hooks *WSHooks) {
+	if hooks != nil {
+		d.client.wsHooks = append(d.client.wsHooks, hooks)
+	}
+}
+
+// use appends the given middlewares to the chain and rebuilds the handler calls are passed to
+func (c *client) use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+	handler := Handler(c.roundTrip)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		handler = c.middlewares[i](handler)
+	}
+	c.handler = handler
+}
+
+// redactParams returns a copy of the given parameters with secrets redacted
+func redactParams(values neturl.Values) neturl.Values {
+	params := make(neturl.Values, len(values))
+	for key, value := range values {
+		if redactedParams[key] {
+			value = []string{redacted}
+		}
+		params[key] = value
+	}
+	return params
+}
+
+// wsName returns the name hooks identify the websocket dialed to the given address by, which carries the given
+// stream. Websockets which carry no stream are user data websockets, which addresses end with a listen key
+func wsName(addr, stream string) string {
+	if stream != "" {
+		return addr
+	}
+	return addr[:strings.LastIndex(addr, "/")+1] + redacted
+}
+
+// headerCounts parses the headers with the given prefix, e.g. X-Mbx-Used-Weight-1m, keyed by their interval suffix
+func headerCounts(header http.Header, prefix string) map[string]int {
+	var counts map[string]int
+	for key, values := range header {
+		if !strings.HasPrefix(key, prefix) || len(values) == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+		if counts == nil {
+			counts = map[string]int{}
+		}
+		counts[strings.ToLower(key[len(prefix):])] = n
+	}
+	return counts
+}
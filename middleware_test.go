@@ -0,0 +1,173 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_Chain(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NotEmpty(t, r.URL.Query().Get("signature"))
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "12")
+		w.Header().Set("X-MBX-ORDER-COUNT-10S", "1")
+		w.Header().Set("X-MBX-ORDER-COUNT-1D", "7")
+		w.Write([]byte(`[]`))
+	})
+	calls := []string{}
+	var seen *Request
+	var outcome *Response
+	client.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			calls = append(calls, "outer")
+			seen = req
+			resp, err := next(req)
+			outcome = resp
+			return resp, err
+		}
+	}, func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			calls = append(calls, "inner")
+			return next(req)
+		}
+	})
+
+	_, err := client.AllOrders(&AllOrdersOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"outer", "inner"}, calls)
+	require.Equal(t, "api/v3/allOrders", seen.Endpoint)
+	require.Equal(t, http.MethodGet, seen.Method)
+	require.True(t, seen.Signed)
+	require.Equal(t, neturl.Values{"symbol": {"ETHBTC"}, "limit": {"500"}}, seen.Params)
+	require.Equal(t, http.StatusOK, outcome.Status)
+	require.Equal(t, map[string]int{"1m": 12}, outcome.UsedWeight)
+	require.Equal(t, map[string]int{"10s": 1, "1d": 7}, outcome.OrderCount)
+	require.True(t, outcome.Latency > 0)
+}
+
+func TestMiddleware_Redaction(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret-key", r.Form.Get("listenKey"))
+	})
+	var params neturl.Values
+	client.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			params = req.Params
+			return next(req)
+		}
+	})
+	require.NoError(t, client.DataStreamKeepAlive("secret-key"))
+	require.Equal(t, neturl.Values{"listenKey": {redacted}}, params)
+}
+
+func TestMiddleware_APIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code":-1003,"msg":"Too many requests."}`))
+	})
+	var decoded error
+	var status int
+	client.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			resp, err := next(req)
+			decoded, status = err, resp.Status
+			return resp, err
+		}
+	})
+	_, err := client.Prices()
+	require.Equal(t, &APIError{Status: http.StatusTooManyRequests, Code: -1003, Msg: "Too many requests."}, err)
+	require.Equal(t, err, decoded)
+	require.Equal(t, http.StatusTooManyRequests, status)
+}
+
+func TestMiddleware_FaultInjection(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("unavailable"))
+			return
+		}
+		w.Write([]byte(`[{"symbol":"ETHBTC","price":"0.06"}]`))
+	})
+	injected := fmt.Errorf("injected")
+	client.Use(func(next Handler) Handler {
+		return func(req *Request) (*Response, error) {
+			switch req.Endpoint {
+			case "api/v1/ping":
+				return nil, injected
+			case "api/v1/time":
+				return &Response{Status: http.StatusOK, Body: []byte(`{"serverTime":1499827319559}`)}, nil
+			}
+			// Retry unavailable responses once
+			resp, err := next(req)
+			if err != nil && resp != nil && resp.Status == http.StatusServiceUnavailable {
+				return next(req)
+			}
+			return resp, err
+		}
+	})
+
+	require.Equal(t, injected, client.Ping())
+	serverTime, err := client.Time()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1499827319559), serverTime.ServerTime)
+	require.Equal(t, 0, requests)
+
+	prices, err := client.Prices()
+	require.NoError(t, err)
+	require.Equal(t, []*SymbolPrice{{Symbol: "ETHBTC", Price: "0.06"}}, prices)
+	require.Equal(t, 2, requests)
+}
+
+func TestWSHooks(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		// Every connection carries a single message
+		conn.WriteMessage(websocket.TextMessage, tradesUpdatePayload)
+		conn.Close()
+	}))
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/listen-key"
+	events := []string{}
+	hooks := wsHooks{&WSHooks{
+		OnConnect: func(addr string, err error) {
+			events = append(events, fmt.Sprintf("connect %s %v", addr, err))
+		},
+		OnDisconnect: func(addr string, err error) {
+			events = append(events, fmt.Sprintf("disconnect %s %v", addr, err != nil))
+		},
+		OnMessage: func(addr string, data []byte) {
+			events = append(events, fmt.Sprintf("message %s %d", addr, len(data)))
+		},
+	}}
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	ws := &TradesWS{newWSWrapper(conn, addr, "", websocket.DefaultDialer, WSOpts{Reconnect: true}, hooks)}
+
+	for i := 0; i < 2; i++ {
+		_, err := ws.Read()
+		require.NoError(t, err)
+	}
+	require.NoError(t, ws.Close())
+	name := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/" + redacted
+	require.Equal(t, []string{
+		fmt.Sprintf("message %s %d", name, len(tradesUpdatePayload)),
+		fmt.Sprintf("disconnect %s true", name),
+		fmt.Sprintf("connect %s <nil>", name),
+		fmt.Sprintf("message %s %d", name, len(tradesUpdatePayload)),
+		fmt.Sprintf("disconnect %s false", name),
+	}, events)
+}
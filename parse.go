@@ -0,0 +1,314 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The hot path payloads, depth levels, klines and depth updates, are decoded by the hand-rolled parsers below rather
+// than by reflection. The payload is converted to a string once, and the decoded strings are sliced out of it, so the
+// decoded values never refer to the read buffer and a payload costs a single allocation besides the decoded value and
+// the slices it fills.
+// Remark: Any decoded string retains the whole payload, e.g. a kept depth level retains the update it was read from.
+// Trades updates are left to encoding/json, the fields of a single trade not being worth the payload copy.
+// Payloads the parsers do not handle, e.g. strings with escape sequences, are decoded by encoding/json instead.
+
+var errParseFallback = errors.New("payload requires the generic decoder")
+
+// jsonScanner reads the tokens of a JSON document in place, its first error stops further reads
+type jsonScanner struct {
+	data string
+	pos  int
+	err  error
+}
+
+func (s *jsonScanner) fail() {
+	if s.err == nil {
+		s.err = errParseFallback
+	}
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next non space byte, or zero at the end of the document
+func (s *jsonScanner) peek() byte {
+	s.skipSpace()
+	if s.err != nil || s.pos >= len(s.data) {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+// consume skips the next byte if it is the given one, and reports whether it was
+func (s *jsonScanner) consume(c byte) bool {
+	if s.peek() != c {
+		return false
+	}
+	s.pos++
+	return true
+}
+
+func (s *jsonScanner) expect(c byte) {
+	if !s.consume(c) {
+		s.fail()
+	}
+}
+
+// str reads a string without escape sequences
+func (s *jsonScanner) str() string {
+	s.expect('"')
+	start := s.pos
+	for s.err == nil && s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '"':
+			s.pos++
+			return s.data[start : s.pos-1]
+		case '\\':
+			s.fail()
+		}
+		s.pos++
+	}
+	s.fail()
+	return ""
+}
+
+// uint reads an unsigned integer, or a string of one
+func (s *jsonScanner) uint() uint64 {
+	quoted := s.consume('"')
+	if s.peek() < '0' || s.peek() > '9' {
+		s.fail()
+	}
+	var n uint64
+	for s.err == nil && s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '9' {
+		d := uint64(s.data[s.pos] - '0')
+		if n > (1<<64-1-d)/10 {
+			s.fail()
+		}
+		n = n*10 + d
+		s.pos++
+	}
+	if quoted {
+		s.expect('"')
+	}
+	return n
+}
+
+func (s *jsonScanner) int() int {
+	n := s.uint()
+	if n > 1<<63-1 {
+		s.fail()
+	}
+	return int(n)
+}
+
+// literal consumes the given literal if it is next
+func (s *jsonScanner) literal(lit string) bool {
+	if s.peek() != lit[0] || len(s.data)-s.pos < len(lit) || s.data[s.pos:s.pos+len(lit)] != lit {
+		return false
+	}
+	s.pos += len(lit)
+	return true
+}
+
+// skip skips the next value of any type
+func (s *jsonScanner) skip() {
+	switch c := s.peek(); {
+	case c == '"':
+		s.str()
+	case c == '{':
+		s.object(func(string) { s.skip() })
+	case c == '[':
+		s.array(s.skip)
+	case c == '-' || (c >= '0' && c <= '9'):
+		s.pos++
+		for s.pos < len(s.data) && isNumberByte(s.data[s.pos]) {
+			s.pos++
+		}
+	case s.literal("true"), s.literal("false"), s.literal("null"):
+	default:
+		s.fail()
+	}
+}
+
+func isNumberByte(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
+
+// object reads an object, calling field for each of its keys, which must read the value of the key
+func (s *jsonScanner) object(field func(key string)) {
+	s.expect('{')
+	if s.consume('}') {
+		return
+	}
+	for s.err == nil {
+		key := s.str()
+		s.expect(':')
+		field(key)
+		if !s.consume(',') {
+			break
+		}
+	}
+	s.expect('}')
+}
+
+// array reads an array, calling elem for each of its elements, which must read the element
+func (s *jsonScanner) array(elem func()) {
+	s.expect('[')
+	if s.consume(']') {
+		return
+	}
+	for s.err == nil {
+		elem()
+		if !s.consume(',') {
+			break
+		}
+	}
+	s.expect(']')
+}
+
+// end fails unless the whole document was read
+func (s *jsonScanner) end() error {
+	if s.skipSpace(); s.pos != len(s.data) {
+		s.fail()
+	}
+	return s.err
+}
+
+// depthElem reads a ["price","quantity"] order book level
+func (s *jsonScanner) depthElem() DepthElem {
+	elem := DepthElem{}
+	s.expect('[')
+	elem.Price = s.str()
+	s.expect(',')
+	elem.Quantity = s.str()
+	// Ignore any further fields, as the JSON decoder did
+	for s.err == nil && s.consume(',') {
+		s.skip()
+	}
+	s.expect(']')
+	return elem
+}
+
+// depthElems reads an array of order book levels
+func (s *jsonScanner) depthElems() []DepthElem {
+	if s.literal("null") {
+		return nil
+	}
+	elems := make([]DepthElem, 0, s.count())
+	s.array(func() { elems = append(elems, s.depthElem()) })
+	return elems
+}
+
+// count counts the elements of the array which is next without reading it, assuming the elements are flat arrays as
+// order book levels are, so that slices are allocated once
+func (s *jsonScanner) count() int {
+	n, depth := 0, 0
+	for i := s.pos; i < len(s.data); i++ {
+		switch s.data[i] {
+		case '[':
+			depth++
+			if depth == 2 {
+				n++
+			}
+		case ']':
+			if depth--; depth == 0 {
+				return n
+			}
+		}
+	}
+	return n
+}
+
+// UnmarshalJSON decodes an order book level of the form ["price","quantity"]
+func (b *DepthElem) UnmarshalJSON(data []byte) error {
+	if b == nil {
+		return fmt.Errorf("UnmarshalJSON on nil pointer")
+	}
+	s := &jsonScanner{data: string(data)}
+	elem := s.depthElem()
+	if err := s.end(); err != nil {
+		return b.unmarshalJSON(data)
+	}
+	*b = elem
+	return nil
+}
+
+// UnmarshalJSON decodes a kline of the form [openTime,"open","high","low","close","volume",closeTime,...]
+func (b *Klines) UnmarshalJSON(data []byte) error {
+	if b == nil {
+		return fmt.Errorf("UnmarshalJSON on nil pointer")
+	}
+	s := &jsonScanner{data: string(data)}
+	k := Klines{}
+	s.expect('[')
+	k.OpenTime = s.uint()
+	s.expect(',')
+	k.OpenPrice = s.str()
+	s.expect(',')
+	k.High = s.str()
+	s.expect(',')
+	k.Low = s.str()
+	s.expect(',')
+	k.ClosePrice = s.str()
+	s.expect(',')
+	k.Volume = s.str()
+	s.expect(',')
+	k.CloseTime = s.uint()
+	s.expect(',')
+	k.QuoteAssetVolume = s.str()
+	s.expect(',')
+	k.Trades = s.int()
+	s.expect(',')
+	k.TakerBuyBaseAssetVolume = s.str()
+	s.expect(',')
+	k.TakerBuyQuoteAssetVolume = s.str()
+	for s.err == nil && s.consume(',') {
+		s.skip()
+	}
+	s.expect(']')
+	if err := s.end(); err != nil {
+		return b.unmarshalJSON(data)
+	}
+	*b = k
+	return nil
+}
+
+// UnmarshalJSON decodes a depth update
+func (u *DepthUpdate) UnmarshalJSON(data []byte) error {
+	s := &jsonScanner{data: string(data)}
+	update := DepthUpdate{}
+	s.object(func(key string) {
+		switch key {
+		case "e":
+			update.EventType = UpdateType(s.str())
+		case "E":
+			update.Time = s.uint()
+		case "s":
+			update.Symbol = s.str()
+		case "u":
+			update.UpdateID = s.int()
+		case "b":
+			update.Bids = s.depthElems()
+		case "a":
+			update.Asks = s.depthElems()
+		default:
+			s.skip()
+		}
+	})
+	if err := s.end(); err != nil {
+		type depthUpdate DepthUpdate
+		return json.Unmarshal(data, (*depthUpdate)(u))
+	}
+	*u = update
+	return nil
+}
@@ -0,0 +1,199 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	depthUpdatePayload  = []byte(`{"e":"depthUpdate","E":1672515782136,"s":"BNBBTC","U":157,"u":160,"b":[["0.0024","10"],["0.0023","100"],["0.0022","5.5"]],"a":[["0.0026","100"],["0.0027","1"]]}`)
+	tradesUpdatePayload = []byte(`{"e":"aggTrade","E":1672515782136,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":1672515782136,"m":true,"M":true}`)
+	klinesPayload       = []byte(`[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","17928899.62484339"]`)
+)
+
+// reflectDepthUpdate decodes the payloads with encoding/json, as a reference for the parsers
+type reflectDepthUpdate DepthUpdate
+
+func TestParse_DepthUpdate(t *testing.T) {
+	update := &DepthUpdate{}
+	require.NoError(t, json.Unmarshal(depthUpdatePayload, update))
+	expected := &reflectDepthUpdate{}
+	require.NoError(t, json.Unmarshal(depthUpdatePayload, expected))
+	require.Equal(t, (*DepthUpdate)(expected), update)
+	require.Equal(t, 160, update.UpdateID)
+	require.Equal(t, DepthElem{Price: "0.0022", Quantity: "5.5"}, update.Bids[2])
+
+	// The decoded values do not refer to the payload, which may be a reused read buffer
+	data := append([]byte(nil), depthUpdatePayload...)
+	require.NoError(t, json.Unmarshal(data, update))
+	for i := range data {
+		data[i] = 'x'
+	}
+	require.Equal(t, (*DepthUpdate)(expected), update)
+}
+
+func TestParse_TradesUpdate(t *testing.T) {
+	update := &TradesUpdate{}
+	require.NoError(t, json.Unmarshal(tradesUpdatePayload, update))
+	require.Equal(t, &TradesUpdate{
+		EventType:             UpdateTypeTrades,
+		Time:                  1672515782136,
+		Symbol:                "BNBBTC",
+		TradeID:               12345,
+		Price:                 "0.001",
+		Quantity:              "100",
+		FirstBreakDownTradeID: 100,
+		LastBreakDownTradeID:  105,
+		TradeTime:             1672515782136,
+		Maker:                 true,
+		BestMatch:             true,
+	}, update)
+
+	// "M" is not folded onto "m", whichever decoder handles the payload
+	for _, payload := range []string{
+		`{"s":"BNBBTC","m":false,"M":true}`,
+		`{"s":"BNB\/BTC","m":false,"M":true}`,
+		`{"s":"BNBBTC","M":true,"m":false}`,
+		`{"s":"BNB\/BTC","M":false,"m":true}`,
+	} {
+		update := &TradesUpdate{}
+		require.NoError(t, json.Unmarshal([]byte(payload), update))
+		require.Equal(t, strings.Contains(payload, `"m":true`), update.Maker, payload)
+		require.Equal(t, strings.Contains(payload, `"M":true`), update.BestMatch, payload)
+	}
+}
+
+func TestParse_Klines(t *testing.T) {
+	klines := []*Klines{}
+	require.NoError(t, json.Unmarshal([]byte("["+string(klinesPayload)+"]"), &klines))
+	require.Equal(t, []*Klines{{
+		OpenTime:                 1499040000000,
+		OpenPrice:                "0.01634790",
+		High:                     "0.80000000",
+		Low:                      "0.01575800",
+		ClosePrice:               "0.01577100",
+		Volume:                   "148976.11427815",
+		CloseTime:                1499644799999,
+		QuoteAssetVolume:         "2434.19055334",
+		Trades:                   308,
+		TakerBuyBaseAssetVolume:  "1756.87402397",
+		TakerBuyQuoteAssetVolume: "28.46694368",
+	}}, klines)
+}
+
+func TestParse_Fallback(t *testing.T) {
+	// Escape sequences and unquoted numbers are left to the generic decoders
+	update := &DepthUpdate{}
+	require.NoError(t, json.Unmarshal([]byte(`{"e":"depthUpdate","s":"BNBBTC","u":1,"b":[[0.0024,10]],"a":[]}`), update))
+	require.Equal(t, &DepthUpdate{
+		EventType: UpdateTypeDepth,
+		Symbol:    "BNBBTC",
+		UpdateID:  1,
+		Bids:      []DepthElem{{Price: "0.0024", Quantity: "10"}},
+		Asks:      []DepthElem{},
+	}, update)
+
+	trades := &TradesUpdate{}
+	require.NoError(t, json.Unmarshal([]byte(`{"s":"BNB\/BTC","a":1}`), trades))
+	require.Equal(t, "BNB/BTC", trades.Symbol)
+	require.Equal(t, 1, trades.TradeID)
+
+	require.Error(t, json.Unmarshal([]byte(`{"s":"BNBBTC","a":"x"}`), trades))
+	require.Error(t, (&Klines{}).UnmarshalJSON([]byte(`[1499040000000,"0.01"]`)))
+}
+
+func TestWSWrapper_BufferReuse(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, depthUpdatePayload)
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"depthUpdate","s":"ETHBTC","u":161,"b":[],"a":[]}`))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	ws := &DepthWS{newWSWrapper(conn, addr, "bnbbtc@depth", websocket.DefaultDialer, WSOpts{}, nil)}
+	defer ws.Close()
+
+	first, err := ws.Read()
+	require.NoError(t, err)
+	second, err := ws.Read()
+	require.NoError(t, err)
+	require.Equal(t, "BNBBTC", first.Symbol)
+	require.Equal(t, DepthElem{Price: "0.0026", Quantity: "100"}, first.Asks[0])
+	require.Equal(t, "ETHBTC", second.Symbol)
+}
+
+func BenchmarkDepthUpdate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		update := &DepthUpdate{}
+		if err := update.UnmarshalJSON(depthUpdatePayload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDepthUpdate_Reflect(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		update := &reflectDepthUpdate{}
+		if err := json.Unmarshal(depthUpdatePayload, update); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDepthElem(b *testing.B) {
+	data := []byte(`["0.0024","10"]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		elem := &DepthElem{}
+		if err := elem.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDepthElem_Split(b *testing.B) {
+	data := []byte(`["0.0024","10"]`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		elem := &DepthElem{}
+		if err := elem.unmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkKlines(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		k := &Klines{}
+		if err := k.UnmarshalJSON(klinesPayload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkKlines_Split(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		k := &Klines{}
+		if err := k.unmarshalJSON(klinesPayload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package binance
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// SBE encoded responses follow the spot schema, which messages start with a header of their block length, template ID,
+// schema ID and schema version, all little endian
+const (
+	sbeContentType   = "application/sbe"
+	sbeSchemaID      = 3
+	sbeSchemaVersion = 1
+	sbeSchema        = "3:1" // sbeSchema is the value of the X-MBX-SBE header, as <schema ID>:<schema version>
+
+	sbeTemplateError        = 100
+	sbeTemplateExchangeInfo = 103
+	sbeTemplateDepth        = 200
+	sbeTemplateKlines       = 203
+	sbeTemplateOrder        = 304
+
+	sbeTemplatePriceFilter = 1
+	sbeTemplateLotSize     = 4
+	sbeTemplateMinNotional = 5
+	sbeTemplateNotional    = 6
+
+	// sbeNull is the null value of optional int64 fields
+	sbeNull = math.MinInt64
+)
+
+var (
+	errSBEUnsupported = errors.New("unsupported sbe schema")
+	errSBETruncated   = errors.New("truncated sbe message")
+
+	// sbeUnsupportedCodes are the error codes of requests which SBE header is invalid or not supported by the server
+	sbeUnsupportedCodes = map[int]bool{-1152: true, -1153: true, -1155: true}
+
+	sbeOrderStatuses = []OrderStatus{
+		OrderStatusNew, OrderStatusPartial, OrderStatusFilled, OrderStatusCanceled, OrderStatusPending,
+		OrderStatusRejected, OrderStatusExpired,
+	}
+	sbeTimesInForce = []TimeInForce{TimeInForceGTC, TimeInForceIOC, TimeInForce("FOK")}
+	sbeOrderTypes   = []OrderType{
+		OrderTypeMarket, OrderTypeLimit, OrderType("STOP_LOSS"), OrderType("STOP_LOSS_LIMIT"),
+		OrderType("TAKE_PROFIT"), OrderType("TAKE_PROFIT_LIMIT"), OrderType("LIMIT_MAKER"),
+	}
+	sbeSides                    = []OrderSide{OrderSideBuy, OrderSideSell}
+	sbeSelfTradePreventionModes = []SelfTradePreventionMode{
+		SelfTradePreventionNone, SelfTradePreventionExpireTaker, SelfTradePreventionExpireMaker,
+		SelfTradePreventionExpireBoth, SelfTradePreventionDecrement,
+	}
+	sbeSymbolStatuses = []SymbolStatus{
+		SymbolStatusTrading, SymbolStatus("END_OF_DAY"), SymbolStatus("HALT"), SymbolStatus("BREAK"),
+	}
+)
+
+// SetSBE sets whether SBE encoded responses are requested for Depth, Klines, QueryOrder and ExchangeInfo, which are
+// decoded into the same structs as their JSON responses
+// Remark: JSON responses are used whenever the server does not support the schema of the client
+func (b *BinanceClient) SetSBE(enabled bool) {
+	b.client.sbe = enabled
+}
+
+// doSBEOrJSON invokes the given GET API command, decoding its response with the given decoder when SBE is enabled
+// and supported, or otherwise unmarshalling the JSON response of the given JSON endpoint into v
+func (b *BinanceClient) doSBEOrJSON(endpoint, sbeEndpoint string, data interface{}, sign bool, v interface{}, template uint16, decode func(d *sbeDecoder)) error {
+	if b.client.sbe {
+		res, sbe, err := b.client.doSBE(http.MethodGet, sbeEndpoint, data, sign)
+		switch {
+		case err == errSBEUnsupported:
+		case err != nil:
+			return err
+		case !sbe:
+			if err := json.Unmarshal(res, v); err != nil {
+				b.client.logDecodeError(sbeEndpoint, err)
+				return err
+			}
+			return nil
+		default:
+			err := decodeSBE(res, template, decode)
+			if err != errSBEUnsupported {
+				if err != nil {
+					b.client.logDecodeError(sbeEndpoint, err)
+				}
+				return err
+			}
+		}
+		orDiscard(b.client.logger).Debug("binance SBE unsupported, falling back to JSON", slog.String("endpoint", sbeEndpoint))
+	}
+	return b.client.doJSON(http.MethodGet, endpoint, data, sign, false, v)
+}
+
+// decodeSBE decodes the given message of the given template, returning errSBEUnsupported for other schemas
+// Remark: Newer versions of the schema only append fields to the blocks, which are skipped by their block length
+func decodeSBE(data []byte, template uint16, decode func(d *sbeDecoder)) error {
+	d := &sbeDecoder{data: data}
+	blockLength, templateID := d.header()
+	if d.err != nil {
+		return d.err
+	}
+	if templateID != template {
+		return errSBEUnsupported
+	}
+	d.block(blockLength, func() { decode(d) })
+	return d.err
+}
+
+// decodeSBEError converts the given SBE encoded error response to an error
+func decodeSBEError(status int, data []byte) error {
+	code, msg := 0, ""
+	err := decodeSBE(data, sbeTemplateError, func(d *sbeDecoder) {
+		code = int(int16(d.u16()))
+		d.i64() // serverTime
+		d.i64() // retryAfter
+		d.end()
+		msg = string(d.next(int(d.u16())))
+	})
+	if err != nil {
+		return fmt.Errorf("status %d: %v", status, err)
+	}
+	return &APIError{Status: status, Code: code, Msg: msg}
+}
+
+// sbeDecoder reads the fields of an SBE message in order, its first error stops further reads
+type sbeDecoder struct {
+	data     []byte
+	pos      int
+	blockEnd int // blockEnd is the end of the current block, to which end skips, or -1 once skipped
+	err      error
+}
+
+// next returns the next n bytes, or zeros once the message is exhausted
+func (d *sbeDecoder) next(n int) []byte {
+	if d.err == nil && (n < 0 || d.pos+n > len(d.data)) {
+		d.err = errSBETruncated
+	}
+	if d.err != nil {
+		return make([]byte, n)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *sbeDecoder) u8() uint8 {
+	return d.next(1)[0]
+}
+
+func (d *sbeDecoder) i8() int8 {
+	return int8(d.u8())
+}
+
+func (d *sbeDecoder) u16() uint16 {
+	return binary.LittleEndian.Uint16(d.next(2))
+}
+
+func (d *sbeDecoder) u32() uint32 {
+	return binary.LittleEndian.Uint32(d.next(4))
+}
+
+func (d *sbeDecoder) i64() int64 {
+	return int64(binary.LittleEndian.Uint64(d.next(8)))
+}
+
+// decimal reads a 64 bit mantissa of the given exponent, null mantissas are decoded as an empty string
+func (d *sbeDecoder) decimal(exponent int8) string {
+	mantissa := d.i64()
+	if mantissa == sbeNull {
+		return ""
+	}
+	return formatDecimal(big.NewInt(mantissa), exponent)
+}
+
+// decimal128 reads a 128 bit two's complement mantissa of the given exponent
+func (d *sbeDecoder) decimal128(exponent int8) string {
+	b := d.next(16)
+	be := make([]byte, 16)
+	for i := range b {
+		be[15-i] = b[i]
+	}
+	mantissa := new(big.Int).SetBytes(be)
+	if be[0]&0x80 != 0 {
+		mantissa.Sub(mantissa, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return formatDecimal(mantissa, exponent)
+}
+
+// varString8 reads a string prefixed with its 8 bit length
+func (d *sbeDecoder) varString8() string {
+	return string(d.next(int(d.u8())))
+}
+
+func (d *sbeDecoder) header() (blockLength, templateID uint16) {
+	blockLength = d.u16()
+	templateID = d.u16()
+	schemaID := d.u16()
+	d.u16() // version
+	if d.err == nil && schemaID != sbeSchemaID {
+		d.err = errSBEUnsupported
+	}
+	return blockLength, templateID
+}
+
+// block decodes a block of the given length with the given function, which calls end once done with the fixed
+// fields, before decoding groups and variable length fields
+func (d *sbeDecoder) block(length uint16, decode func()) {
+	outer := d.blockEnd
+	d.blockEnd = d.pos + int(length)
+	decode()
+	d.end()
+	d.blockEnd = outer
+}
+
+// end skips the remaining fields of the current block, unknown to this version of the schema
+func (d *sbeDecoder) end() {
+	if d.blockEnd < 0 {
+		return
+	}
+	if d.err == nil && d.pos > d.blockEnd {
+		d.err = errSBEUnsupported
+	}
+	if d.err == nil && d.blockEnd > d.pos {
+		d.next(d.blockEnd - d.pos)
+	}
+	d.blockEnd = -1
+}
+
+// group decodes the entries of a group, which dimension is a 16 bit block length and a 32 bit count
+func (d *sbeDecoder) group(decode func()) {
+	blockLength := d.u16()
+	d.entries(blockLength, int(d.u32()), decode)
+}
+
+// group16 decodes the entries of a group, which dimension is a 16 bit block length and a 16 bit count
+func (d *sbeDecoder) group16(decode func()) {
+	blockLength := d.u16()
+	d.entries(blockLength, int(d.u16()), decode)
+}
+
+func (d *sbeDecoder) entries(blockLength uint16, count int, decode func()) {
+	// Every entry takes at least its block, which bounds the count of a corrupted message
+	if d.err == nil && blockLength > 0 && count > (len(d.data)-d.pos)/int(blockLength) {
+		d.err = errSBETruncated
+	}
+	for i := 0; i < count && d.err == nil; i++ {
+		d.block(blockLength, decode)
+	}
+}
+
+// formatDecimal formats the given mantissa of the given exponent as a decimal string
+func formatDecimal(mantissa *big.Int, exponent int8) string {
+	if exponent >= 0 {
+		return new(big.Int).Mul(mantissa, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)).String()
+	}
+	digits := new(big.Int).Abs(mantissa).String()
+	scale := int(-exponent)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	s := digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	if mantissa.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// sbeBool decodes a boolean enum
+func sbeBool(v uint8) bool {
+	return v == 1
+}
+
+// depth decodes a DepthResponse
+func (d *sbeDecoder) depth(depth *Depth) {
+	depth.LastUpdateID = int(d.i64())
+	priceExponent, qtyExponent := d.i8(), d.i8()
+	d.end()
+	levels := func(elems *[]DepthElem) {
+		*elems = []DepthElem{}
+		d.group(func() {
+			*elems = append(*elems, DepthElem{Price: d.decimal(priceExponent), Quantity: d.decimal(qtyExponent)})
+		})
+	}
+	levels(&depth.Bids)
+	levels(&depth.Asks)
+}
+
+// klines decodes a KlinesResponse
+// Remark: Quote volumes are in the quote asset, of the exponent of prices
+func (d *sbeDecoder) klines(klines *[]*Klines) {
+	priceExponent, qtyExponent := d.i8(), d.i8()
+	d.end()
+	d.group(func() {
+		k := &Klines{}
+		k.OpenTime = uint64(d.i64())
+		k.OpenPrice = d.decimal(priceExponent)
+		k.High = d.decimal(priceExponent)
+		k.Low = d.decimal(priceExponent)
+		k.ClosePrice = d.decimal(priceExponent)
+		k.Volume = d.decimal128(qtyExponent)
+		k.CloseTime = uint64(d.i64())
+		k.QuoteAssetVolume = d.decimal128(priceExponent)
+		k.Trades = int(d.i64())
+		k.TakerBuyBaseAssetVolume = d.decimal128(qtyExponent)
+		k.TakerBuyQuoteAssetVolume = d.decimal128(priceExponent)
+		*klines = append(*klines, k)
+	})
+}
+
+// order decodes an OrderResponse
+func (d *sbeDecoder) order(order *QueryOrder) {
+	priceExponent, qtyExponent := d.i8(), d.i8()
+	order.OrderID = int(d.i64())
+	order.OrderListID = -1
+	if id := d.i64(); id != sbeNull {
+		order.OrderListID = int(id)
+	}
+	order.Price = d.decimal(priceExponent)
+	order.OrigQty = d.decimal(qtyExponent)
+	order.ExecutedQty = d.decimal(qtyExponent)
+	order.CumulativeQuoteQty = d.decimal(priceExponent)
+	if i := int(d.u8()); i < len(sbeOrderStatuses) {
+		order.Status = sbeOrderStatuses[i]
+	}
+	if i := int(d.u8()); i < len(sbeTimesInForce) {
+		order.TimeInForce = sbeTimesInForce[i]
+	}
+	if i := int(d.u8()); i < len(sbeOrderTypes) {
+		order.Type = sbeOrderTypes[i]
+	}
+	if i := int(d.u8()); i < len(sbeSides) {
+		order.Side = sbeSides[i]
+	}
+	order.StopPrice = d.decimal(priceExponent)
+	d.i64() // trailingDelta
+	d.i64() // trailingTime
+	order.IcebergQty = d.decimal(qtyExponent)
+	order.Time = uint64(d.i64())
+	order.UpdateTime = uint64(d.i64())
+	order.Working = sbeBool(d.u8())
+	if t := d.i64(); t != sbeNull {
+		order.WorkingTime = uint64(t)
+	}
+	order.OrigQuoteOrderQty = d.decimal(priceExponent)
+	if i := int(d.u8()); i < len(sbeSelfTradePreventionModes) {
+		order.SelfTradePreventionMode = sbeSelfTradePreventionModes[i]
+	}
+	d.end()
+	order.Symbol = d.varString8()
+	order.ClientOrderID = d.varString8()
+}
+
+// exchangeInfo decodes an ExchangeInfoResponse, of which only the symbols are kept
+func (d *sbeDecoder) exchangeInfo(info *ExchangeInfo) {
+	d.end()
+	d.group16(func() {}) // rateLimits
+	d.group16(func() {   // exchangeFilters
+		d.end()
+		d.varString8()
+	})
+	info.Symbols = []SymbolInfo{}
+	d.group(func() {
+		symbol := SymbolInfo{}
+		if i := int(d.u8()); i < len(sbeSymbolStatuses) {
+			symbol.Status = sbeSymbolStatuses[i]
+		}
+		symbol.BaseAssetPrecision = int(d.u8())
+		symbol.QuoteAssetPrecision = int(d.u8())
+		d.u8() // baseCommissionPrecision
+		d.u8() // quoteCommissionPrecision
+		orderTypes := d.u16()
+		for i, orderType := range sbeOrderTypes {
+			if orderTypes&(1<<uint(i)) != 0 {
+				symbol.OrderTypes = append(symbol.OrderTypes, orderType)
+			}
+		}
+		symbol.Iceberg = sbeBool(d.u8())
+		d.end()
+		d.group16(func() {
+			d.end()
+			if filter, ok := decodeSBEFilter(d.next(int(d.u8()))); ok {
+				symbol.Filters = append(symbol.Filters, filter)
+			}
+		})
+		d.group16(func() { // permissionSets
+			d.end()
+			d.group16(func() {
+				d.end()
+				d.varString8()
+			})
+		})
+		symbol.Symbol = d.varString8()
+		symbol.BaseAsset = d.varString8()
+		symbol.QuoteAsset = d.varString8()
+		info.Symbols = append(info.Symbols, symbol)
+	})
+	d.group16(func() { // sors
+		d.end()
+		d.group16(func() {
+			d.end()
+			d.varString8()
+		})
+		d.varString8()
+	})
+}
+
+// decodeSBEFilter decodes the given symbol filter, itself an SBE message, ok is false for filters not kept by
+// SymbolInfoFilter
+func decodeSBEFilter(data []byte) (filter SymbolInfoFilter, ok bool) {
+	d := &sbeDecoder{data: data}
+	blockLength, templateID := d.header()
+	d.block(blockLength, func() {
+		switch templateID {
+		case sbeTemplatePriceFilter:
+			exponent := d.i8()
+			filter = SymbolInfoFilter{
+				Type:     FilterTypePrice,
+				MinPrice: d.decimal(exponent),
+				MaxPrice: d.decimal(exponent),
+				TickSize: d.decimal(exponent),
+			}
+		case sbeTemplateLotSize:
+			exponent := d.i8()
+			filter = SymbolInfoFilter{
+				Type:     FilterTypeLotSize,
+				MinQty:   d.decimal(exponent),
+				MaxQty:   d.decimal(exponent),
+				StepSize: d.decimal(exponent),
+			}
+		case sbeTemplateMinNotional:
+			filter = SymbolInfoFilter{Type: FilterTypeMinNotional, MinNotional: d.decimal(d.i8())}
+		case sbeTemplateNotional:
+			filter = SymbolInfoFilter{Type: FilterTypeNotional, MinNotional: d.decimal(d.i8())}
+		}
+	})
+	return filter, d.err == nil && filter.Type != ""
+}
@@ -0,0 +1,254 @@
+package binance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sbeEncoder writes little endian SBE fields, for serving SBE responses in tests
+type sbeEncoder struct {
+	bytes.Buffer
+}
+
+func (e *sbeEncoder) put(values ...interface{}) *sbeEncoder {
+	for _, v := range values {
+		binary.Write(&e.Buffer, binary.LittleEndian, v)
+	}
+	return e
+}
+
+// message writes a message header of the given block length and template
+func (e *sbeEncoder) message(blockLength, template, schemaID uint16) *sbeEncoder {
+	return e.put(blockLength, template, schemaID, uint16(sbeSchemaVersion))
+}
+
+func (e *sbeEncoder) group(blockLength uint16, count uint32) *sbeEncoder {
+	return e.put(blockLength, count)
+}
+
+func (e *sbeEncoder) group16(blockLength, count uint16) *sbeEncoder {
+	return e.put(blockLength, count)
+}
+
+func (e *sbeEncoder) varString8(s string) *sbeEncoder {
+	e.put(uint8(len(s)))
+	e.WriteString(s)
+	return e
+}
+
+// int128 writes the given mantissa as a 128 bit two's complement
+func (e *sbeEncoder) int128(mantissa int64) *sbeEncoder {
+	v := big.NewInt(mantissa)
+	if mantissa < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	be := v.FillBytes(make([]byte, 16))
+	for i := 0; i < 8; i++ {
+		be[i], be[15-i] = be[15-i], be[i]
+	}
+	e.Write(be)
+	return e
+}
+
+// sbeHandler serves the given SBE response to requests accepting SBE, and the given JSON response otherwise
+func sbeHandler(t *testing.T, path string, sbe []byte, json string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != sbeContentType {
+			w.Write([]byte(json))
+			return
+		}
+		require.Equal(t, path, r.URL.Path)
+		require.Equal(t, "3:1", r.Header.Get("X-MBX-SBE"))
+		w.Header().Set("Content-Type", sbeContentType)
+		w.Write(sbe)
+	}
+}
+
+func TestSBE_Depth(t *testing.T) {
+	e := &sbeEncoder{}
+	e.message(10, sbeTemplateDepth, sbeSchemaID).put(int64(1027024), int8(-8), int8(-8))
+	e.group(16, 1).put(int64(400000000), int64(43100000000))
+	e.group(16, 2).put(int64(400000200), int64(1200000000), int64(400000300), int64(5))
+	client := newTestClient(t, sbeHandler(t, "/api/v3/depth", e.Bytes(), ""))
+	client.SetSBE(true)
+
+	depth, err := client.Depth(&DepthOpts{Symbol: "ETHBTC", Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, &Depth{
+		LastUpdateID: 1027024,
+		Bids:         []DepthElem{{Price: "4.00000000", Quantity: "431.00000000"}},
+		Asks: []DepthElem{
+			{Price: "4.00000200", Quantity: "12.00000000"},
+			{Price: "4.00000300", Quantity: "0.00000005"},
+		},
+	}, depth)
+}
+
+func TestSBE_Klines(t *testing.T) {
+	e := &sbeEncoder{}
+	// The block is one byte longer than known to the decoder, as of a newer version of the schema
+	e.message(3, sbeTemplateKlines, sbeSchemaID).put(int8(-2), int8(-3), uint8(0))
+	e.group(120, 1).put(int64(1499040000000), int64(1), int64(12345), int64(-5), int64(100))
+	e.int128(148976110).put(int64(1499644799999)).int128(-2434190)
+	e.put(int64(308)).int128(1756870).int128(28)
+	client := newTestClient(t, sbeHandler(t, "/api/v3/klines", e.Bytes(), ""))
+	client.SetSBE(true)
+
+	klines, err := client.Klines(&KlinesOpts{Symbol: "ETHBTC", Interval: KlineInterval1d})
+	require.NoError(t, err)
+	require.Equal(t, []*Klines{{
+		OpenTime:                 1499040000000,
+		OpenPrice:                "0.01",
+		High:                     "123.45",
+		Low:                      "-0.05",
+		ClosePrice:               "1.00",
+		Volume:                   "148976.110",
+		CloseTime:                1499644799999,
+		QuoteAssetVolume:         "-24341.90",
+		Trades:                   308,
+		TakerBuyBaseAssetVolume:  "1756.870",
+		TakerBuyQuoteAssetVolume: "0.28",
+	}}, klines)
+}
+
+func TestSBE_QueryOrder(t *testing.T) {
+	e := &sbeEncoder{}
+	e.message(120, sbeTemplateOrder, sbeSchemaID).put(int8(-8), int8(-8), int64(28), int64(sbeNull))
+	e.put(int64(10000000), int64(1000000000), int64(0), int64(0))
+	e.put(uint8(0), uint8(0), uint8(1), uint8(1))
+	e.put(int64(sbeNull), int64(sbeNull), int64(sbeNull), int64(0))
+	e.put(int64(1507725176595), int64(1507725176596), uint8(1), int64(1507725176595), int64(0), uint8(0))
+	e.varString8("LTCBTC").varString8("myOrder1")
+	client := newTestClient(t, sbeHandler(t, "/api/v3/order", e.Bytes(), ""))
+	client.SetSBE(true)
+
+	order, err := client.QueryOrder(&QueryOrderOpts{Symbol: "LTCBTC", OrderID: 28})
+	require.NoError(t, err)
+	require.Equal(t, &QueryOrder{
+		Symbol:                  "LTCBTC",
+		OrderID:                 28,
+		OrderListID:             -1,
+		ClientOrderID:           "myOrder1",
+		Price:                   "0.10000000",
+		OrigQty:                 "10.00000000",
+		ExecutedQty:             "0.00000000",
+		CumulativeQuoteQty:      "0.00000000",
+		Status:                  OrderStatusNew,
+		TimeInForce:             TimeInForceGTC,
+		Type:                    OrderTypeLimit,
+		Side:                    OrderSideSell,
+		IcebergQty:              "0.00000000",
+		Time:                    1507725176595,
+		UpdateTime:              1507725176596,
+		Working:                 true,
+		WorkingTime:             1507725176595,
+		OrigQuoteOrderQty:       "0.00000000",
+		SelfTradePreventionMode: SelfTradePreventionNone,
+	}, order)
+}
+
+func TestSBE_ExchangeInfo(t *testing.T) {
+	priceFilter := &sbeEncoder{}
+	priceFilter.message(25, sbeTemplatePriceFilter, sbeSchemaID).put(int8(-8), int64(1), int64(100000000000), int64(1))
+	lotSize := &sbeEncoder{}
+	lotSize.message(25, sbeTemplateLotSize, sbeSchemaID).put(int8(-3), int64(1), int64(9000000), int64(1))
+	unknown := &sbeEncoder{}
+	unknown.message(1, 99, sbeSchemaID).put(uint8(0))
+
+	e := &sbeEncoder{}
+	e.message(0, sbeTemplateExchangeInfo, sbeSchemaID)
+	e.group16(11, 1).put(uint8(0), uint8(0), uint8(1), int64(6000))
+	e.group16(0, 0)
+	e.group(8, 1).put(uint8(0), uint8(8), uint8(8), uint8(8), uint8(8), uint16(1<<1|1<<6), uint8(1))
+	e.group16(0, 3).varString8(priceFilter.String()).varString8(lotSize.String()).varString8(unknown.String())
+	e.group16(0, 1).group16(0, 1).varString8("SPOT")
+	e.varString8("ETHBTC").varString8("ETH").varString8("BTC")
+	e.group16(0, 0)
+	client := newTestClient(t, sbeHandler(t, "/api/v3/exchangeInfo", e.Bytes(), ""))
+	client.SetSBE(true)
+
+	info, err := client.ExchangeInfo()
+	require.NoError(t, err)
+	require.Equal(t, &ExchangeInfo{Symbols: []SymbolInfo{{
+		Symbol:              "ETHBTC",
+		Status:              SymbolStatusTrading,
+		BaseAsset:           "ETH",
+		BaseAssetPrecision:  8,
+		QuoteAsset:          "BTC",
+		QuoteAssetPrecision: 8,
+		OrderTypes:          []OrderType{OrderTypeLimit, OrderType("LIMIT_MAKER")},
+		Iceberg:             true,
+		Filters: []SymbolInfoFilter{
+			{Type: FilterTypePrice, MinPrice: "0.00000001", MaxPrice: "1000.00000000", TickSize: "0.00000001"},
+			{Type: FilterTypeLotSize, MinQty: "0.001", MaxQty: "9000.000", StepSize: "0.001"},
+		},
+	}}}, info)
+}
+
+func TestSBE_Fallback(t *testing.T) {
+	const depthJSON = `{"lastUpdateId":1,"bids":[["4.00000000","431.00000000"]],"asks":[]}`
+	expected := &Depth{LastUpdateID: 1, Bids: []DepthElem{{Price: "4.00000000", Quantity: "431.00000000"}}, Asks: []DepthElem{}}
+
+	// The server rejects the requested schema
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Accept") == sbeContentType {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1153,"msg":"Invalid SBE schema ID or version specified in the X-MBX-SBE header."}`))
+			return
+		}
+		require.Equal(t, "/api/v1/depth", r.URL.Path)
+		w.Write([]byte(depthJSON))
+	})
+	client.SetSBE(true)
+	depth, err := client.Depth(&DepthOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	require.Equal(t, expected, depth)
+	require.Equal(t, 2, requests)
+
+	// The server responds with another schema
+	e := &sbeEncoder{}
+	e.message(10, sbeTemplateDepth, 4).put(int64(2), int8(-8), int8(-8))
+	client = newTestClient(t, sbeHandler(t, "/api/v3/depth", e.Bytes(), depthJSON))
+	client.SetSBE(true)
+	depth, err = client.Depth(&DepthOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	require.Equal(t, expected, depth)
+
+	// The server responds with JSON regardless
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(depthJSON))
+	})
+	client.SetSBE(true)
+	depth, err = client.Depth(&DepthOpts{Symbol: "ETHBTC"})
+	require.NoError(t, err)
+	require.Equal(t, expected, depth)
+}
+
+func TestSBE_Errors(t *testing.T) {
+	e := &sbeEncoder{}
+	e.message(18, sbeTemplateError, sbeSchemaID).put(int16(-2013), int64(sbeNull), int64(sbeNull))
+	e.put(uint16(len("Order does not exist."))).WriteString("Order does not exist.")
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", sbeContentType)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(e.Bytes())
+	})
+	client.SetSBE(true)
+	_, err := client.QueryOrder(&QueryOrderOpts{Symbol: "LTCBTC", OrderID: 28})
+	require.EqualError(t, err, "status 400: code -2013: Order does not exist.")
+
+	// A truncated response fails rather than falling back to JSON
+	e = &sbeEncoder{}
+	e.message(10, sbeTemplateDepth, sbeSchemaID).put(int64(2), int8(-8), int8(-8)).group(16, 1000)
+	client = newTestClient(t, sbeHandler(t, "/api/v3/depth", e.Bytes(), ""))
+	client.SetSBE(true)
+	_, err = client.Depth(&DepthOpts{Symbol: "ETHBTC"})
+	require.Equal(t, errSBETruncated, err)
+}
@@ -0,0 +1,397 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	maxStreamsPerConn         = 1024             // maxStreamsPerConn is the maximal number of streams a single connection may carry
+	maxStreamMessagesInWindow = 5                // maxStreamMessagesInWindow is the maximal number of messages sent within a rate window
+	streamRateWindow          = time.Second      // streamRateWindow is the window on which sent messages are limited
+	streamResponseTimeout     = 10 * time.Second // streamResponseTimeout is the time to wait for a method response
+)
+
+// StreamMethod represents a method frame that can be sent over a stream connection
+type StreamMethod string
+
+const (
+	StreamMethodSubscribe         StreamMethod = "SUBSCRIBE"
+	StreamMethodUnsubscribe       StreamMethod = "UNSUBSCRIBE"
+	StreamMethodListSubscriptions StreamMethod = "LIST_SUBSCRIPTIONS"
+	StreamMethodSetProperty       StreamMethod = "SET_PROPERTY"
+	StreamMethodGetProperty       StreamMethod = "GET_PROPERTY"
+)
+
+// StreamHandlers holds the handlers the incoming updates of a stream connection are routed to
+// Remark: Handlers are called from the connection read loop, hence a blocking handler delays all following updates.
+// Updates for which there is no handler are discarded
+type StreamHandlers struct {
+	Depth          func(stream string, update *DepthUpdate)         // Depth handles depth updates
+	PartialDepth   func(stream string, update *PartialDepthUpdate)  // PartialDepth handles partial depth updates
+	Klines         func(stream string, update *KlinesUpdate)        // Klines handles klines updates
+	Trades         func(stream string, update *TradesUpdate)        // Trades handles aggregated trades updates
+	RawTrade       func(stream string, update *RawTradeUpdate)      // RawTrade handles raw trades updates
+	Ticker         func(stream string, update *TickerUpdate)        // Ticker handles ticker updates
+	AllTickers     func(stream string, updates []*TickerUpdate)     // AllTickers handles all market tickers updates
+	MiniTicker     func(stream string, update *MiniTickerUpdate)    // MiniTicker handles mini ticker updates
+	AllMiniTickers func(stream string, updates []*MiniTickerUpdate) // AllMiniTickers handles all market mini tickers updates
+	BookTicker     func(stream string, update *BookTickerUpdate)    // BookTicker handles book ticker updates
+	RollingTicker  func(stream string, update *RollingTickerUpdate) // RollingTicker handles rolling window ticker updates
+	AvgPrice       func(stream string, update *AvgPriceUpdate)      // AvgPrice handles average price updates
+	Error          func(err error)                                  // Error handles decode errors and the error that terminated the connection
+}
+
+// StreamError represents an error returned by the server in response to a method frame
+type StreamError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("stream error %d: %s", e.Code, e.Msg)
+}
+
+type streamRequest struct {
+	Method StreamMethod  `json:"method"`
+	Params []interface{} `json:"params,omitempty"`
+	ID     int           `json:"id"`
+}
+
+type streamResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *StreamError    `json:"error"`
+}
+
+// StreamConn is a stream websocket that allows changing the subscribed streams while it is open
+type StreamConn struct {
+	conn     *websocket.Conn
+	addr     string // addr is the address the connection was dialed to, which identifies it to the hooks
+	handlers StreamHandlers
+	opts     WSOpts
+	hooks    wsHooks
+	logger   *slog.Logger
+
+	writeMu sync.Mutex  // writeMu serializes frames sent over the connection
+	sent    []time.Time // sent holds the send times of the latest frames, used to enforce the message rate limit
+
+	mu      sync.Mutex
+	nextID  int
+	pending map[int]chan *streamResponse
+	streams map[string]bool
+	closed  bool
+
+	done chan struct{}
+	err  error
+}
+
+// newStreamConn starts serving the given connection
+// Remark: Only the ping interval and the read timeout of the given options apply to stream connections
+func newStreamConn(conn *websocket.Conn, addr string, handlers *StreamHandlers, streams []string, opts WSOpts, hooks wsHooks, logger *slog.Logger) *StreamConn {
+	s := &StreamConn{
+		conn:     conn,
+		addr:     addr,
+		handlers: *handlers,
+		opts:     opts,
+		hooks:    hooks,
+		logger:   orDiscard(logger),
+		pending:  map[int]chan *streamResponse{},
+		streams:  map[string]bool{},
+		done:     make(chan struct{}),
+	}
+	for _, stream := range streams {
+		s.streams[stream] = true
+	}
+	setReadTimeout(conn, opts.ReadTimeout)
+	// Control frames count against the message rate limit, hence pongs and pings are sent through the limiter
+	conn.SetPingHandler(func(data string) error {
+		if opts.ReadTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(opts.ReadTimeout))
+		}
+		err := s.writeControl(websocket.PongMessage, []byte(data))
+		if err != nil && err != websocket.ErrCloseSent {
+			return err
+		}
+		return nil
+	})
+	if opts.PingInterval > 0 {
+		go pingLoop(func() error { return s.writeControl(websocket.PingMessage, nil) }, opts.PingInterval, s.done)
+	}
+	go s.readLoop()
+	return s
+}
+
+// Subscribe subscribes the connection to the given streams
+func (s *StreamConn) Subscribe(streams ...string) error {
+	// The new streams are reserved until the server responds, for concurrent subscriptions to respect the limit
+	s.mu.Lock()
+	reserved := []string{}
+	for _, stream := range streams {
+		if !s.streams[stream] {
+			reserved = append(reserved, stream)
+		}
+	}
+	if len(s.streams)+len(reserved) > maxStreamsPerConn {
+		s.mu.Unlock()
+		return fmt.Errorf("at most %d streams are allowed per connection", maxStreamsPerConn)
+	}
+	for _, stream := range reserved {
+		s.streams[stream] = true
+	}
+	s.mu.Unlock()
+	if _, err := s.call(StreamMethodSubscribe, stringParams(streams)); err != nil {
+		s.mu.Lock()
+		for _, stream := range reserved {
+			delete(s.streams, stream)
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes the connection from the given streams
+func (s *StreamConn) Unsubscribe(streams ...string) error {
+	if _, err := s.call(StreamMethodUnsubscribe, stringParams(streams)); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	for _, stream := range streams {
+		delete(s.streams, stream)
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// ListSubscriptions retrieves the streams the connection is currently subscribed to
+func (s *StreamConn) ListSubscriptions() ([]string, error) {
+	res, err := s.call(StreamMethodListSubscriptions, nil)
+	if err != nil {
+		return nil, err
+	}
+	streams := []string{}
+	if err := json.Unmarshal(res, &streams); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.streams = map[string]bool{}
+	for _, stream := range streams {
+		s.streams[stream] = true
+	}
+	s.mu.Unlock()
+	return streams, nil
+}
+
+// SetProperty sets the given connection property, e.g. "combined"
+// Remark: Updates received while the combined property is off are routed by their event type
+func (s *StreamConn) SetProperty(name string, value interface{}) error {
+	_, err := s.call(StreamMethodSetProperty, []interface{}{name, value})
+	return err
+}
+
+// Done returns a channel which is closed once the connection terminates
+func (s *StreamConn) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close closes the connection
+func (s *StreamConn) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	return s.conn.Close()
+}
+
+// call sends the given method frame and waits for its correlated response
+func (s *StreamConn) call(method StreamMethod, params []interface{}) (json.RawMessage, error) {
+	s.mu.Lock()
+	if s.err != nil {
+		s.mu.Unlock()
+		return nil, s.err
+	}
+	s.nextID++
+	id := s.nextID
+	resc := make(chan *streamResponse, 1)
+	s.pending[id] = resc
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}()
+	if err := s.write(&streamRequest{Method: method, Params: params, ID: id}); err != nil {
+		return nil, err
+	}
+	select {
+	case res := <-resc:
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		return res.Result, nil
+	case <-s.done:
+		return nil, s.err
+	case <-time.After(streamResponseTimeout):
+		return nil, fmt.Errorf("no response for %s request %d", method, id)
+	}
+}
+
+// write sends the given frame, waiting as long as needed to respect the connection message rate limit
+func (s *StreamConn) write(req *streamRequest) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.throttle()
+	return s.conn.WriteJSON(req)
+}
+
+// writeControl sends the given control frame, which counts against the connection message rate limit as well
+func (s *StreamConn) writeControl(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.throttle()
+	return s.conn.WriteControl(messageType, data, time.Now().Add(wsWriteWait))
+}
+
+// throttle waits as long as needed for a frame to be sent within the message rate limit, and records its sending
+// Remark: writeMu must be held
+func (s *StreamConn) throttle() {
+	if len(s.sent) == maxStreamMessagesInWindow {
+		if wait := streamRateWindow - time.Since(s.sent[0]); wait > 0 {
+			s.logger.Debug("binance stream waiting for the message rate limit", slog.String("addr", s.addr), slog.Duration("wait", wait))
+			time.Sleep(wait)
+		}
+		s.sent = s.sent[1:]
+	}
+	s.sent = append(s.sent, time.Now())
+}
+
+// readLoop reads incoming messages, resolving method responses and routing updates to the handlers
+func (s *StreamConn) readLoop() {
+	for {
+		if s.opts.ReadTimeout > 0 {
+			s.conn.SetReadDeadline(time.Now().Add(s.opts.ReadTimeout))
+		}
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			s.terminate(err)
+			return
+		}
+		s.hooks.message(s.addr, data)
+		msg := &struct {
+			ID *int `json:"id"`
+			streamResponse
+			Stream    string          `json:"stream"`
+			Data      json.RawMessage `json:"data"`
+			EventType UpdateType      `json:"e"`
+			Time      uint64          `json:"E"` // Time keeps the event time from case-folding onto the event type
+		}{}
+		if err := json.Unmarshal(data, msg); err != nil {
+			s.decodeFailed(err)
+			continue
+		}
+		if msg.ID != nil {
+			s.mu.Lock()
+			resc, ok := s.pending[*msg.ID]
+			s.mu.Unlock()
+			if ok {
+				resc <- &msg.streamResponse
+			}
+			continue
+		}
+		var update *CombinedUpdate
+		if msg.Stream != "" {
+			update, err = decodeStreamUpdate(msg.Stream, streamType(msg.Stream), msg.Data)
+		} else {
+			update, err = decodeStreamUpdate("", updateStreamTypes[msg.EventType], data)
+		}
+		if err != nil {
+			s.decodeFailed(err)
+			continue
+		}
+		s.dispatch(update)
+	}
+}
+
+func (s *StreamConn) dispatch(update *CombinedUpdate) {
+	switch {
+	case update.Depth != nil && s.handlers.Depth != nil:
+		s.handlers.Depth(update.Stream, update.Depth)
+	case update.Klines != nil && s.handlers.Klines != nil:
+		s.handlers.Klines(update.Stream, update.Klines)
+	case update.Trades != nil && s.handlers.Trades != nil:
+		s.handlers.Trades(update.Stream, update.Trades)
+	case update.PartialDepth != nil && s.handlers.PartialDepth != nil:
+		s.handlers.PartialDepth(update.Stream, update.PartialDepth)
+	case update.RawTrade != nil && s.handlers.RawTrade != nil:
+		s.handlers.RawTrade(update.Stream, update.RawTrade)
+	case update.Ticker != nil && s.handlers.Ticker != nil:
+		s.handlers.Ticker(update.Stream, update.Ticker)
+	case update.AllTickers != nil && s.handlers.AllTickers != nil:
+		s.handlers.AllTickers(update.Stream, update.AllTickers)
+	case update.MiniTicker != nil && s.handlers.MiniTicker != nil:
+		s.handlers.MiniTicker(update.Stream, update.MiniTicker)
+	case update.AllMiniTickers != nil && s.handlers.AllMiniTickers != nil:
+		s.handlers.AllMiniTickers(update.Stream, update.AllMiniTickers)
+	case update.BookTicker != nil && s.handlers.BookTicker != nil:
+		s.handlers.BookTicker(update.Stream, update.BookTicker)
+	case update.RollingTicker != nil && s.handlers.RollingTicker != nil:
+		s.handlers.RollingTicker(update.Stream, update.RollingTicker)
+	case update.AvgPrice != nil && s.handlers.AvgPrice != nil:
+		s.handlers.AvgPrice(update.Stream, update.AvgPrice)
+	}
+}
+
+// decodeFailed logs and handles the failure to decode a message
+func (s *StreamConn) decodeFailed(err error) {
+	s.logger.Warn("binance stream decode failed", slog.String("addr", s.addr), slog.Any("error", err))
+	s.handleError(err)
+}
+
+func (s *StreamConn) handleError(err error) {
+	if s.handlers.Error != nil {
+		s.handlers.Error(err)
+	}
+}
+
+// terminate records the error which terminated the connection and releases the pending calls
+func (s *StreamConn) terminate(err error) {
+	s.mu.Lock()
+	closed := s.closed
+	s.err = err
+	if closed {
+		s.err = fmt.Errorf("connection closed")
+	}
+	s.mu.Unlock()
+	close(s.done)
+	if closed {
+		s.hooks.disconnect(s.addr, nil)
+	} else {
+		s.hooks.disconnect(s.addr, err)
+		s.handleError(err)
+	}
+}
+
+// updateStreamTypes maps update event types to the stream types carrying them
+// Remark: Updates without an event type, e.g. book tickers and all market arrays, can only be routed by stream name
+var updateStreamTypes = map[UpdateType]string{
+	UpdateTypeDepth:  "depth",
+	UpdateTypeKline:  "kline",
+	UpdateTypeTrades: "aggTrade",
+
+	UpdateTypeRawTrade:   "trade",
+	UpdateTypeTicker:     "ticker",
+	UpdateTypeMiniTicker: "miniTicker",
+	UpdateTypeAvgPrice:   "avgPrice",
+}
+
+func stringParams(values []string) []interface{} {
+	params := make([]interface{}, len(values))
+	for i, v := range values {
+		params[i] = v
+	}
+	return params
+}
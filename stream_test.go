@@ -0,0 +1,197 @@
+package binance
+
+import (
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newStreamServer starts a local stream server answering method frames the way the exchange does
+func newStreamServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		streams := []string{}
+		combined := true
+		for {
+			req := &streamRequest{}
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			res := map[string]interface{}{"id": req.ID, "result": nil}
+			switch req.Method {
+			case StreamMethodSubscribe:
+				for _, p := range req.Params {
+					if !strings.Contains(p.(string), "@") {
+						res = map[string]interface{}{"id": req.ID, "error": map[string]interface{}{"code": 2, "msg": "Invalid request"}}
+						break
+					}
+					streams = append(streams, p.(string))
+				}
+			case StreamMethodListSubscriptions:
+				res["result"] = streams
+			case StreamMethodSetProperty:
+				if req.Params[0] == "combined" {
+					combined = req.Params[1].(bool)
+				}
+			}
+			require.NoError(t, conn.WriteJSON(res))
+			if req.Method == StreamMethodSubscribe {
+				var update interface{} = map[string]interface{}{"e": "aggTrade", "E": 1672515782136, "s": "ETHBTC", "a": 1}
+				if combined {
+					update = map[string]interface{}{"stream": "ethbtc@aggTrade", "data": update}
+				}
+				data, _ := json.Marshal(update)
+				require.NoError(t, conn.WriteMessage(websocket.TextMessage, data))
+			}
+		}
+	}))
+}
+
+func TestStreamConn(t *testing.T) {
+	server := newStreamServer(t)
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+
+	trades := make(chan *TradesUpdate, 1)
+	s := newStreamConn(conn, addr, &StreamHandlers{
+		Trades: func(stream string, update *TradesUpdate) {
+			require.Equal(t, "ethbtc@aggTrade", stream)
+			trades <- update
+		},
+	}, nil, WSOpts{}, nil, nil)
+	defer s.Close()
+
+	require.NoError(t, s.Subscribe("ethbtc@aggTrade"))
+	require.Equal(t, "ETHBTC", (<-trades).Symbol)
+
+	streams, err := s.ListSubscriptions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ethbtc@aggTrade"}, streams)
+
+	err = s.Subscribe("invalid")
+	require.IsType(t, &StreamError{}, err)
+	require.Equal(t, 2, err.(*StreamError).Code)
+
+	many := make([]string, maxStreamsPerConn)
+	for i := range many {
+		many[i] = strings.Repeat("a", i+1) + "@aggTrade"
+	}
+	require.Error(t, s.Subscribe(many...))
+}
+
+func TestStreamConn_Raw(t *testing.T) {
+	server := newStreamServer(t)
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+
+	trades := make(chan *TradesUpdate, 1)
+	errs := make(chan error, 1)
+	s := newStreamConn(conn, addr, &StreamHandlers{
+		Trades: func(stream string, update *TradesUpdate) {
+			trades <- update
+		},
+		Error: func(err error) {
+			errs <- err
+		},
+	}, nil, WSOpts{}, nil, nil)
+	defer s.Close()
+
+	// Updates received without the combined property are routed by their event type
+	require.NoError(t, s.SetProperty("combined", false))
+	require.NoError(t, s.Subscribe("ethbtc@aggTrade"))
+	select {
+	case update := <-trades:
+		require.Equal(t, "ETHBTC", update.Symbol)
+		require.Equal(t, uint64(1672515782136), update.Time)
+	case err := <-errs:
+		require.NoError(t, err)
+	}
+}
+
+func TestStreamConn_ConcurrentSubscribe(t *testing.T) {
+	server := newStreamServer(t)
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	s := newStreamConn(conn, addr, &StreamHandlers{}, nil, WSOpts{}, nil, nil)
+	defer s.Close()
+
+	many := make([]string, maxStreamsPerConn-1)
+	for i := range many {
+		many[i] = strings.Repeat("a", i+1) + "@aggTrade"
+	}
+	require.NoError(t, s.Subscribe(many...))
+	// Only one of two concurrent subscriptions fits within the limit
+	errs := make(chan error, 2)
+	for _, stream := range []string{"ethbtc@trade", "ltcbtc@trade"} {
+		go func(stream string) {
+			errs <- s.Subscribe(stream)
+		}(stream)
+	}
+	failed := 0
+	for i := 0; i < 2; i++ {
+		if <-errs != nil {
+			failed++
+		}
+	}
+	require.Equal(t, 1, failed)
+	require.Len(t, s.streams, maxStreamsPerConn)
+
+	// Streams failing to subscribe are released
+	require.NoError(t, s.Unsubscribe("a@aggTrade"))
+	require.Error(t, s.Subscribe("invalid"))
+	require.Len(t, s.streams, maxStreamsPerConn-1)
+}
+
+func TestStreamConn_ControlFramesRateLimit(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	frames := make(chan time.Time, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error {
+			frames <- time.Now()
+			return nil
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	s := newStreamConn(conn, addr, &StreamHandlers{}, nil, WSOpts{PingInterval: 10 * time.Millisecond}, nil, nil)
+	defer s.Close()
+
+	// Pings sent every 10ms are held back to 5 per second
+	time.Sleep(1500 * time.Millisecond)
+	pings := []time.Time{}
+	for len(frames) > 0 {
+		pings = append(pings, <-frames)
+	}
+	require.True(t, len(pings) > maxStreamMessagesInWindow)
+	for i := maxStreamMessagesInWindow; i < len(pings); i++ {
+		require.True(t, pings[i].Sub(pings[i-maxStreamMessagesInWindow]) >= streamRateWindow-10*time.Millisecond)
+	}
+}
@@ -0,0 +1,87 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Sub-account endpoints
+// Remark: All endpoints are available to master accounts only
+
+// SubAccounts lists the sub-accounts of the master account
+func (b *BinanceClient) SubAccounts(opts *SubAccountsOpts) ([]*SubAccount, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &SubAccounts{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/sub-account/list", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp.SubAccounts, nil
+}
+
+// SubAccountAssets get the spot balances of a sub-account
+func (b *BinanceClient) SubAccountAssets(opts *SubAccountAssetsOpts) ([]*Balance, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Email == "" {
+		return nil, fmt.Errorf("email is missing")
+	}
+	resp := &SubAccountAssets{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v3/sub-account/assets", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp.Balances, nil
+}
+
+// SubAccountTransfer transfers funds between the master account and its sub-accounts, or between two sub-accounts
+func (b *BinanceClient) SubAccountTransfer(opts *SubAccountTransferOpts) (*SubAccountTransfer, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.FromEmail == "" && opts.ToEmail == "" {
+		return nil, fmt.Errorf("from email or to email must be set")
+	}
+	if opts.FromAccountType == "" || opts.ToAccountType == "" || opts.Asset == "" || opts.Amount == "" {
+		return nil, fmt.Errorf("account types, asset or amount are missing")
+	}
+	resp := &SubAccountTransfer{}
+	if err := b.client.doJSON(http.MethodPost, "sapi/v1/sub-account/universalTransfer", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SubAccountTransferHistory get the transfers between the master account and its sub-accounts
+func (b *BinanceClient) SubAccountTransferHistory(opts *SubAccountTransferHistoryOpts) (*SubAccountTransferHistory, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &SubAccountTransferHistory{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/sub-account/universalTransfer", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SubAccountFuturesSummary get the USD-M futures accounts summary of all sub-accounts
+func (b *BinanceClient) SubAccountFuturesSummary(opts *SubAccountSummaryOpts) (*SubAccountFuturesSummary, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &SubAccountFuturesSummary{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/sub-account/futures/accountSummary", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SubAccountMarginSummary get the margin accounts summary of all sub-accounts
+func (b *BinanceClient) SubAccountMarginSummary() (*SubAccountMarginSummary, error) {
+	resp := &SubAccountMarginSummary{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/sub-account/margin/accountSummary", nil, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
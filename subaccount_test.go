@@ -0,0 +1,42 @@
+package binance
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestSubAccountTransfer(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sapi/v1/sub-account/universalTransfer", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Empty(t, r.PostForm.Get("fromEmail"))
+		require.Equal(t, "bot@example.com", r.PostForm.Get("toEmail"))
+		require.Equal(t, "USDT_FUTURE", r.PostForm.Get("toAccountType"))
+		w.Write([]byte(`{"tranId":11945860693,"clientTranId":"rebalance-1"}`))
+	})
+	_, err := client.SubAccountTransfer(&SubAccountTransferOpts{FromAccountType: SubAccountTypeSpot, ToAccountType: SubAccountTypeUSDTFutures, Asset: "USDT", Amount: "10"})
+	require.Error(t, err)
+
+	res, err := client.SubAccountTransfer(&SubAccountTransferOpts{
+		ToEmail:         "bot@example.com",
+		FromAccountType: SubAccountTypeSpot,
+		ToAccountType:   SubAccountTypeUSDTFutures,
+		ClientTranID:    "rebalance-1",
+		Asset:           "USDT",
+		Amount:          "10",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(11945860693), res.TranID)
+}
+
+func TestSubAccountAssets(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sapi/v3/sub-account/assets", r.URL.Path)
+		require.Equal(t, "bot@example.com", r.URL.Query().Get("email"))
+		w.Write([]byte(`{"balances":[{"asset":"BTC","free":"0.1","locked":"0"}]}`))
+	})
+	balances, err := client.SubAccountAssets(&SubAccountAssetsOpts{Email: "bot@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, []*Balance{{Asset: "BTC", Free: "0.1", Locked: "0"}}, balances)
+}
@@ -0,0 +1,228 @@
+package binance
+
+import (
+	"context"
+	"errors"
+)
+
+const defaultSubscribeBufferSize = 64
+
+// ErrSlowConsumer is reported when a subscription is disconnected since its consumer did not keep up with the updates
+var ErrSlowConsumer = errors.New("slow consumer, subscription disconnected")
+
+// SlowConsumerPolicy represents the action taken when an update arrives while the subscription channel is full
+type SlowConsumerPolicy int
+
+const (
+	SlowConsumerBlock      SlowConsumerPolicy = iota // SlowConsumerBlock waits until the consumer receives, pausing the reads
+	SlowConsumerDropOldest                           // SlowConsumerDropOldest discards the oldest buffered update
+	SlowConsumerDropNewest                           // SlowConsumerDropNewest discards the arriving update
+	SlowConsumerDisconnect                           // SlowConsumerDisconnect closes the subscription with ErrSlowConsumer
+)
+
+// SubscribeOpts are used to configure channel based subscriptions
+type SubscribeOpts struct {
+	BufferSize int                // BufferSize is the capacity of the updates channels. Default 64
+	Policy     SlowConsumerPolicy // Policy is the action taken when an updates channel is full. Default SlowConsumerBlock
+}
+
+// SubscribeDepth streams depth updates for the given symbol until the given context is cancelled
+// Remark: The error channel receives the error that terminated the subscription, if any, after which all channels are closed
+func (b *BinanceClient) SubscribeDepth(ctx context.Context, symbol string, opts *SubscribeOpts) (<-chan *DepthUpdate, <-chan error, error) {
+	ws, err := b.DepthWS(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := newSubscription(ctx, ws.wsWrapper, opts)
+	updates := make(chan *DepthUpdate, s.opts.BufferSize)
+	var update *DepthUpdate
+	ops := newChannelOps(updates, &update)
+	go s.run(func() (*channelOps, error) {
+		var err error
+		update, err = ws.Read()
+		return ops, err
+	}, func() { close(updates) })
+	return updates, s.errc, nil
+}
+
+// SubscribeKlines streams klines updates for the given symbol with the given interval until the given context is cancelled
+// Remark: The error channel receives the error that terminated the subscription, if any, after which all channels are closed
+func (b *BinanceClient) SubscribeKlines(ctx context.Context, symbol string, interval KlineInterval, opts *SubscribeOpts) (<-chan *KlinesUpdate, <-chan error, error) {
+	ws, err := b.KlinesWS(symbol, interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := newSubscription(ctx, ws.wsWrapper, opts)
+	updates := make(chan *KlinesUpdate, s.opts.BufferSize)
+	var update *KlinesUpdate
+	ops := newChannelOps(updates, &update)
+	go s.run(func() (*channelOps, error) {
+		var err error
+		update, err = ws.Read()
+		return ops, err
+	}, func() { close(updates) })
+	return updates, s.errc, nil
+}
+
+// SubscribeTrades streams aggregated trades updates for the given symbol until the given context is cancelled
+// Remark: The error channel receives the error that terminated the subscription, if any, after which all channels are closed
+func (b *BinanceClient) SubscribeTrades(ctx context.Context, symbol string, opts *SubscribeOpts) (<-chan *TradesUpdate, <-chan error, error) {
+	ws, err := b.TradesWS(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := newSubscription(ctx, ws.wsWrapper, opts)
+	updates := make(chan *TradesUpdate, s.opts.BufferSize)
+	var update *TradesUpdate
+	ops := newChannelOps(updates, &update)
+	go s.run(func() (*channelOps, error) {
+		var err error
+		update, err = ws.Read()
+		return ops, err
+	}, func() { close(updates) })
+	return updates, s.errc, nil
+}
+
+// SubscribeAccountInfo streams account and order updates of the given datastream key until the given context is cancelled
+// Remark: The error channel receives the error that terminated the subscription, if any, after which all channels are closed.
+// The slow consumer policy is applied on each of the updates channels separately. Other user data events are discarded,
+// use UserStream to receive all of them
+func (b *BinanceClient) SubscribeAccountInfo(ctx context.Context, listenKey string, opts *SubscribeOpts) (<-chan *AccountUpdate, <-chan *OrderUpdate, <-chan error, error) {
+	ws, err := b.AccountInfoWS(listenKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	s := newSubscription(ctx, ws.wsWrapper, opts)
+	accountUpdates := make(chan *AccountUpdate, s.opts.BufferSize)
+	orderUpdates := make(chan *OrderUpdate, s.opts.BufferSize)
+	var accountUpdate *AccountUpdate
+	var orderUpdate *OrderUpdate
+	accountOps := newChannelOps(accountUpdates, &accountUpdate)
+	orderOps := newChannelOps(orderUpdates, &orderUpdate)
+	go s.run(func() (*channelOps, error) {
+		for {
+			event, err := ws.ReadEvent()
+			if err != nil {
+				return nil, err
+			}
+			switch update := event.(type) {
+			case *AccountUpdate:
+				accountUpdate = update
+				return accountOps, nil
+			case *OrderUpdate:
+				orderUpdate = update
+				return orderOps, nil
+			}
+		}
+	}, func() {
+		close(accountUpdates)
+		close(orderUpdates)
+	})
+	return accountUpdates, orderUpdates, s.errc, nil
+}
+
+// channelOps are the operations a subscription applies on a typed updates channel for the update that was last read
+type channelOps struct {
+	trySend    func() bool                     // trySend sends the update if the channel is ready to receive it
+	send       func(done <-chan struct{}) bool // send blocks until the update is sent or done is closed
+	dropOldest func()                          // dropOldest discards the oldest buffered update, if any
+}
+
+// newChannelOps returns the operations on the given updates channel for the update the given pointer refers to
+func newChannelOps[T any](updates chan T, update *T) *channelOps {
+	return &channelOps{
+		trySend: func() bool {
+			select {
+			case updates <- *update:
+				return true
+			default:
+				return false
+			}
+		},
+		send: func(done <-chan struct{}) bool {
+			select {
+			case updates <- *update:
+				return true
+			case <-done:
+				return false
+			}
+		},
+		dropOldest: func() {
+			select {
+			case <-updates:
+			default:
+			}
+		},
+	}
+}
+
+// subscription pumps the updates of a websocket into typed channels
+type subscription struct {
+	ctx  context.Context
+	ws   *wsWrapper
+	opts SubscribeOpts
+	errc chan error
+}
+
+func newSubscription(ctx context.Context, ws *wsWrapper, opts *SubscribeOpts) *subscription {
+	s := &subscription{ctx: ctx, ws: ws, errc: make(chan error, 1)}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if s.opts.BufferSize <= 0 {
+		s.opts.BufferSize = defaultSubscribeBufferSize
+	}
+	return s
+}
+
+// run reads updates using the given read function and delivers each of them using the returned channel operations,
+// until the context is cancelled or an error occurs. closeChans is called once the subscription terminates
+func (s *subscription) run(read func() (*channelOps, error), closeChans func()) {
+	stop := make(chan struct{})
+	defer close(stop)
+	defer close(s.errc)
+	defer closeChans()
+	defer s.ws.Close()
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			s.ws.Close()
+		case <-stop:
+		}
+	}()
+
+	for {
+		ops, err := read()
+		if err != nil {
+			if s.ctx.Err() == nil {
+				s.errc <- err
+			}
+			return
+		}
+		if !s.deliver(ops) {
+			return
+		}
+	}
+}
+
+// deliver hands over the last read update according to the slow consumer policy, and returns whether the
+// subscription should keep running
+func (s *subscription) deliver(ops *channelOps) bool {
+	switch s.opts.Policy {
+	case SlowConsumerDropNewest:
+		ops.trySend()
+		return true
+	case SlowConsumerDropOldest:
+		for !ops.trySend() {
+			ops.dropOldest()
+		}
+		return true
+	case SlowConsumerDisconnect:
+		if ops.trySend() {
+			return true
+		}
+		s.errc <- ErrSlowConsumer
+		return false
+	}
+	return ops.send(s.ctx.Done())
+}
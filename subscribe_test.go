@@ -0,0 +1,105 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestWSClient returns a client which websockets are served by the given handler
+func newTestWSClient(t *testing.T, handler func(conn *websocket.Conn)) *BinanceClient {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	}))
+	t.Cleanup(server.Close)
+	// The connections dialed to the exchange are redirected to the server, which certificate is issued to example.com
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	tlsConfig.ServerName = "example.com"
+	client := NewBinanceClient("key", "secret")
+	client.dialer = &websocket.Dialer{
+		NetDial: func(network, addr string) (net.Conn, error) {
+			return net.Dial(network, server.Listener.Addr().String())
+		},
+		TLSClientConfig: tlsConfig,
+	}
+	return client
+}
+
+// subscribeTrades subscribes on a local server which sends the given number of trades updates and closes the connection
+func subscribeTrades(t *testing.T, ctx context.Context, count int, opts *SubscribeOpts) (<-chan *TradesUpdate, <-chan error) {
+	client := newTestWSClient(t, func(conn *websocket.Conn) {
+		for i := 1; i <= count; i++ {
+			msg := fmt.Sprintf(`{"e":"aggTrade","s":"ETHBTC","a":%d}`, i)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+				return
+			}
+		}
+	})
+	updates, errc, err := client.SubscribeTrades(ctx, "ETHBTC", opts)
+	require.NoError(t, err)
+	return updates, errc
+}
+
+func TestSubscription_DropOldest(t *testing.T) {
+	updates, errc := subscribeTrades(t, context.Background(), 10, &SubscribeOpts{BufferSize: 2, Policy: SlowConsumerDropOldest})
+	require.Error(t, <-errc)
+	ids := []int{}
+	for update := range updates {
+		ids = append(ids, update.TradeID)
+	}
+	require.Equal(t, []int{9, 10}, ids)
+}
+
+func TestSubscription_DropNewest(t *testing.T) {
+	updates, errc := subscribeTrades(t, context.Background(), 10, &SubscribeOpts{BufferSize: 2, Policy: SlowConsumerDropNewest})
+	require.Error(t, <-errc)
+	ids := []int{}
+	for update := range updates {
+		ids = append(ids, update.TradeID)
+	}
+	require.Equal(t, []int{1, 2}, ids)
+}
+
+func TestSubscription_Disconnect(t *testing.T) {
+	updates, errc := subscribeTrades(t, context.Background(), 10, &SubscribeOpts{BufferSize: 2, Policy: SlowConsumerDisconnect})
+	require.Equal(t, ErrSlowConsumer, <-errc)
+	for range updates {
+	}
+}
+
+func TestSubscription_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, errc := subscribeTrades(t, ctx, 10, &SubscribeOpts{BufferSize: 1})
+	require.Equal(t, 1, (<-updates).TradeID)
+	cancel()
+	for range updates {
+	}
+	_, ok := <-errc
+	require.False(t, ok)
+}
+
+func TestSubscription_AccountInfo(t *testing.T) {
+	client := newTestWSClient(t, func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"outboundAccountInfo","E":1564034571105,"m":10}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"listStatus","E":1564035303637,"s":"ETHBTC"}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"executionReport","E":1499405658658,"s":"ETHBTC","i":4293153}`))
+	})
+	accountUpdates, orderUpdates, errc, err := client.SubscribeAccountInfo(context.Background(), "listen-key", nil)
+	require.NoError(t, err)
+	require.Equal(t, 10, (<-accountUpdates).MakerCommission)
+	require.Equal(t, 4293153, (<-orderUpdates).OrderID)
+	require.Error(t, <-errc)
+	_, ok := <-accountUpdates
+	require.False(t, ok)
+}
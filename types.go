@@ -26,6 +26,10 @@ const (
 	OrderStatusExpired  OrderStatus = "EXPIRED"
 	OrderStatusReplaced OrderStatus = "REPLACED"
 	OrderStatusTrade    OrderStatus = "TRADE"
+
+	OrderStatusPendingNew      OrderStatus = "PENDING_NEW"
+	OrderStatusExpiredInMatch  OrderStatus = "EXPIRED_IN_MATCH"
+	OrderStatusTradePrevention OrderStatus = "TRADE_PREVENTION"
 )
 
 type OrderFailure string
@@ -43,6 +47,44 @@ const (
 	OrderFailureAccountSettle     OrderFailure = "ACCOUNT_CANNOT_SETTLE"
 )
 
+// SelfTradePreventionMode represents the action taken when an order would match another order of the same account
+type SelfTradePreventionMode string
+
+const (
+	SelfTradePreventionNone        SelfTradePreventionMode = "NONE"
+	SelfTradePreventionExpireTaker SelfTradePreventionMode = "EXPIRE_TAKER"
+	SelfTradePreventionExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
+	SelfTradePreventionExpireBoth  SelfTradePreventionMode = "EXPIRE_BOTH"
+	SelfTradePreventionDecrement   SelfTradePreventionMode = "DECREMENT"
+)
+
+// ContingencyType represents the type of an order list
+type ContingencyType string
+
+const (
+	ContingencyTypeOCO ContingencyType = "OCO"
+	ContingencyTypeOTO ContingencyType = "OTO"
+)
+
+// ListStatusType represents the status of an order list
+type ListStatusType string
+
+const (
+	ListStatusTypeResponse    ListStatusType = "RESPONSE"
+	ListStatusTypeExecStarted ListStatusType = "EXEC_STARTED"
+	ListStatusTypeUpdated     ListStatusType = "UPDATED"
+	ListStatusTypeAllDone     ListStatusType = "ALL_DONE"
+)
+
+// ListOrderStatus represents the status of the orders of an order list
+type ListOrderStatus string
+
+const (
+	ListOrderStatusExecuting ListOrderStatus = "EXECUTING"
+	ListOrderStatusAllDone   ListOrderStatus = "ALL_DONE"
+	ListOrderStatusReject    ListOrderStatus = "REJECT"
+)
+
 type OrderSide string
 
 const (
@@ -112,12 +154,9 @@ type DepthElem struct {
 	Price    string `json:"price"`
 }
 
-// UnmarshalJSON unmarshal the given depth raw data and converts to depth struct
-func (b *DepthElem) UnmarshalJSON(data []byte) error {
-	if b == nil {
-		return fmt.Errorf("UnmarshalJSON on nil pointer")
-	}
-
+// unmarshalJSON unmarshal the given depth raw data and converts to depth struct
+// Remark: This is the fallback of UnmarshalJSON, for payloads its parser does not handle
+func (b *DepthElem) unmarshalJSON(data []byte) error {
 	if len(data) == 0 {
 		return nil
 	}
@@ -160,12 +199,9 @@ type Klines struct {
 	TakerBuyQuoteAssetVolume string
 }
 
-// UnmarshalJSON unmarshal the given depth raw data and converts to depth struct
-func (b *Klines) UnmarshalJSON(data []byte) error {
-	if b == nil {
-		return fmt.Errorf("UnmarshalJSON on nil pointer")
-	}
-
+// unmarshalJSON unmarshal the given depth raw data and converts to depth struct
+// Remark: This is the fallback of UnmarshalJSON, for payloads its parser does not handle
+func (b *Klines) unmarshalJSON(data []byte) error {
 	if len(data) == 0 {
 		return nil
 	}
@@ -253,19 +289,26 @@ type QueryOrderOpts struct {
 }
 
 type QueryOrder struct {
-	Symbol        string      `json:"symbol"`
-	OrderID       int         `json:"orderId"`
-	ClientOrderID string      `json:"clientOrderId"`
-	Price         string      `json:"price"`
-	OrigQty       string      `json:"origQty"`
-	ExecutedQty   string      `json:"executedQty"`
-	Status        OrderStatus `json:"status"`
-	TimeInForce   TimeInForce `json:"timeInForce"`
-	Type          OrderType   `json:"type"`
-	Side          OrderSide   `json:"side"`
-	StopPrice     string      `json:"stopPrice"`
-	IcebergQty    string      `json:"IcebergQty"`
-	Time          uint64      `json:"time"`
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 int                     `json:"orderId"`
+	OrderListID             int                     `json:"orderListId"` // OrderListID is the ID of the order list the order belongs to, or -1
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   string                  `json:"price"`
+	OrigQty                 string                  `json:"origQty"`
+	ExecutedQty             string                  `json:"executedQty"`
+	CumulativeQuoteQty      string                  `json:"cummulativeQuoteQty"` // CumulativeQuoteQty is the accumulated quote quantity of filled trades
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	StopPrice               string                  `json:"stopPrice"`
+	IcebergQty              string                  `json:"icebergQty"`
+	Time                    uint64                  `json:"time"`
+	UpdateTime              uint64                  `json:"updateTime"`
+	Working                 bool                    `json:"isWorking"` // Working indicates whether the order is on the book
+	WorkingTime             uint64                  `json:"workingTime"`
+	OrigQuoteOrderQty       string                  `json:"origQuoteOrderQty"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
 }
 
 // Remark: Either OrderID or OrigOrderiD must be set
@@ -288,11 +331,14 @@ type OpenOrdersOpts struct {
 }
 
 // AllOrdersOpts represents the opts used for querying orders of the given symbol
-// Remark: If orderId is set, it will get orders >= that orderId. Otherwise most recent orders are returned
+// Remark: If orderId is set, it will get orders >= that orderId. Otherwise most recent orders are returned.
+// The distance between StartTime and EndTime must be less than 24 hours
 type AllOrdersOpts struct {
-	Symbol  string `url:"symbol"`  // Symbol is the symbol to fetch orders for
-	OrderID int    `url:"orderId"` // OrderID, if set, will filter all recent orders newer from the given ID
-	Limit   int    `url:"limit"`   // Limit is the maximal number of elements to receive. Max 500
+	Symbol    string `url:"symbol"`              // Symbol is the symbol to fetch orders for
+	OrderID   int    `url:"orderId,omitempty"`   // OrderID, if set, will filter all recent orders newer from the given ID
+	StartTime uint64 `url:"startTime,omitempty"` // StartTime, if set, filters orders created from the given time
+	EndTime   uint64 `url:"endTime,omitempty"`   // EndTime, if set, filters orders created until the given time
+	Limit     int    `url:"limit"`               // Limit is the maximal number of elements to receive. Max 1000
 }
 
 type Balance struct {
@@ -301,27 +347,107 @@ type Balance struct {
 	Locked string `json:"locked"`
 }
 
+// AccountOpts represents the opts used for querying the account information
+type AccountOpts struct {
+	OmitZeroBalances bool `url:"omitZeroBalances,omitempty"` // OmitZeroBalances, if set, omits balances of assets without a free or locked amount
+}
+
 type AccountInfo struct {
-	MakerCommission  int        `json:"makerCommission"`
-	TakerCommission  int        `json:"takerCommission"`
-	BuyerCommission  int        `json:"buyerCommission"`
-	SellerCommission int        `json:"sellerCommission"`
-	CanTrade         bool       `json:"canTrade"`
-	CanWithdraw      bool       `json:"canWithdraw"`
-	CanDeposit       bool       `json:"canDeposit"`
-	Balances         []*Balance `json:"balances"`
+	MakerCommission            int             `json:"makerCommission"`
+	TakerCommission            int             `json:"takerCommission"`
+	BuyerCommission            int             `json:"buyerCommission"`
+	SellerCommission           int             `json:"sellerCommission"`
+	CommissionRates            CommissionRates `json:"commissionRates"`
+	CanTrade                   bool            `json:"canTrade"`
+	CanWithdraw                bool            `json:"canWithdraw"`
+	CanDeposit                 bool            `json:"canDeposit"`
+	Brokered                   bool            `json:"brokered"`
+	RequireSelfTradePrevention bool            `json:"requireSelfTradePrevention"`
+	PreventSor                 bool            `json:"preventSor"`
+	UpdateTime                 uint64          `json:"updateTime"`
+	AccountType                string          `json:"accountType"` // AccountType is the account type, e.g. "SPOT"
+	Balances                   []*Balance      `json:"balances"`
+	Permissions                []string        `json:"permissions"` // Permissions are the account permissions, e.g. "SPOT" and "MARGIN"
+	UID                        int64           `json:"uid"`
 }
 
-type TradesOpts struct {
+// CommissionRates represents commission rates as fractions, e.g. "0.00100000" for 0.1%
+type CommissionRates struct {
+	Maker  string `json:"maker"`
+	Taker  string `json:"taker"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
+}
+
+type AccountCommissionOpts struct {
 	Symbol string `url:"symbol"`
-	Limit  int    `url:"limit"`  // Limit is the maximal number of elements to receive. Max 500
-	FromID int    `url:"fromId"` // FromID is trade ID to fetch from. Default gets most recent trades
+}
+
+// AccountCommission represents the commission rates of the account for a specific symbol
+type AccountCommission struct {
+	Symbol             string          `json:"symbol"`
+	StandardCommission CommissionRates `json:"standardCommission"` // StandardCommission are the commission rates of orders
+	SpecialCommission  CommissionRates `json:"specialCommission"`  // SpecialCommission are the commission rates of special orders
+	TaxCommission      CommissionRates `json:"taxCommission"`      // TaxCommission are the tax rates of orders
+	Discount           struct {
+		EnabledForAccount bool   `json:"enabledForAccount"`
+		EnabledForSymbol  bool   `json:"enabledForSymbol"`
+		DiscountAsset     string `json:"discountAsset"` // DiscountAsset is the asset commission is discounted for when paid with
+		Discount          string `json:"discount"`      // Discount is the discount rate when paying with the discount asset
+	} `json:"discount"`
+}
+
+// AccountStatus represents the account status, e.g. "Normal"
+type AccountStatus struct {
+	Data string `json:"data"`
+}
+
+// APITradingStatus represents the trading status of the API key, which is locked once the trading rules are violated
+type APITradingStatus struct {
+	Data struct {
+		Locked             bool           `json:"isLocked"`
+		PlannedRecoverTime uint64         `json:"plannedRecoverTime"` // PlannedRecoverTime is the time the lock ends, if locked
+		TriggerCondition   map[string]int `json:"triggerCondition"`   // TriggerCondition are the thresholds of the trading rules, e.g. "GCR"
+		UpdateTime         uint64         `json:"updateTime"`
+	} `json:"data"`
+}
+
+// APIKeyPermissions represents the permissions and restrictions of the API key
+type APIKeyPermissions struct {
+	IPRestrict                     bool   `json:"ipRestrict"`
+	CreateTime                     uint64 `json:"createTime"`
+	EnableReading                  bool   `json:"enableReading"`
+	EnableSpotAndMarginTrading     bool   `json:"enableSpotAndMarginTrading"`
+	EnableWithdrawals              bool   `json:"enableWithdrawals"`
+	EnableInternalTransfer         bool   `json:"enableInternalTransfer"`
+	EnableMargin                   bool   `json:"enableMargin"`
+	EnableFutures                  bool   `json:"enableFutures"`
+	EnableVanillaOptions           bool   `json:"enableVanillaOptions"`
+	EnablePortfolioMarginTrading   bool   `json:"enablePortfolioMarginTrading"`
+	PermitsUniversalTransfer       bool   `json:"permitsUniversalTransfer"`
+	TradingAuthorityExpirationTime uint64 `json:"tradingAuthorityExpirationTime"`
+}
+
+// TradesOpts represents the opts used for querying account trades of the given symbol
+// Remark: FromID can't be combined with StartTime and EndTime, and the distance between StartTime and EndTime must be
+// less than 24 hours
+type TradesOpts struct {
+	Symbol    string `url:"symbol"`
+	OrderID   int    `url:"orderId,omitempty"`   // OrderID, if set, filters the trades of the given order
+	StartTime uint64 `url:"startTime,omitempty"` // StartTime, if set, filters trades executed from the given time
+	EndTime   uint64 `url:"endTime,omitempty"`   // EndTime, if set, filters trades executed until the given time
+	FromID    int    `url:"fromId,omitempty"`    // FromID is trade ID to fetch from. Default gets most recent trades
+	Limit     int    `url:"limit"`               // Limit is the maximal number of elements to receive. Max 1000
 }
 
 type Trades struct {
+	Symbol          string `json:"symbol"`
 	ID              int    `json:"id"`
+	OrderID         int    `json:"orderId"`
+	OrderListID     int    `json:"orderListId"` // OrderListID is the ID of the order list the order belongs to, or -1
 	Price           string `json:"price"`
 	Qty             string `json:"qty"`
+	QuoteQty        string `json:"quoteQty"`
 	Commission      string `json:"commission"`
 	CommissionAsset string `json:"commissionAsset"`
 	Time            uint64 `json:"time"`
@@ -380,6 +506,7 @@ const (
 	FilterTypePrice       FilterType = "PRICE_FILTER"
 	FilterTypeLotSize     FilterType = "LOT_SIZE"
 	FilterTypeMinNotional FilterType = "MIN_NOTIONAL"
+	FilterTypeNotional    FilterType = "NOTIONAL"
 )
 
 type SymbolInfoFilter struct {
@@ -397,4 +524,5 @@ type SymbolInfoFilter struct {
 
 	// MIN_NOTIONAL paramters
 	MinNotional string `json:"minNotional"`
+	Notional    string `json:"notional"` // Notional is the minimal notional of futures symbols
 }
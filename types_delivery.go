@@ -0,0 +1,125 @@
+package binance
+
+type DeliveryExchangeInfo struct {
+	Timezone   string                `json:"timezone"`
+	ServerTime uint64                `json:"serverTime"`
+	Symbols    []*DeliverySymbolInfo `json:"symbols"`
+}
+
+// DeliverySymbolInfo represents a COIN-M contract, either perpetual or delivered at DeliveryDate
+type DeliverySymbolInfo struct {
+	Symbol            string             `json:"symbol"`
+	Pair              string             `json:"pair"`
+	ContractType      ContractType       `json:"contractType"`
+	ContractSize      int                `json:"contractSize"` // ContractSize is the value of a single contract in quote asset, e.g. 100 USD
+	ContractStatus    string             `json:"contractStatus"`
+	DeliveryDate      uint64             `json:"deliveryDate"`
+	OnboardDate       uint64             `json:"onboardDate"`
+	BaseAsset         string             `json:"baseAsset"`
+	QuoteAsset        string             `json:"quoteAsset"`
+	MarginAsset       string             `json:"marginAsset"`
+	PricePrecision    int                `json:"pricePrecision"`
+	QuantityPrecision int                `json:"quantityPrecision"`
+	LiquidationFee    string             `json:"liquidationFee"`
+	MarketTakeBound   string             `json:"marketTakeBound"`
+	OrderTypes        []FuturesOrderType `json:"orderTypes"`
+	TimeInForce       []TimeInForce      `json:"timeInForce"`
+	Filters           []SymbolInfoFilter `json:"filters"`
+}
+
+type DeliveryMarkPriceOpts struct {
+	Symbol string `url:"symbol,omitempty"`
+	Pair   string `url:"pair,omitempty"` // Pair, if set, receives the mark prices of all contracts of the pair
+}
+
+// DeliveryFundingRateOpts represents the opts used for querying the funding rate history of a perpetual contract
+type DeliveryFundingRateOpts struct {
+	Symbol    string `url:"symbol"`
+	StartTime uint64 `url:"startTime,omitempty"`
+	EndTime   uint64 `url:"endTime,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 100, max 1000
+}
+
+type DeliveryPriceOpts struct {
+	Pair string `url:"pair"`
+}
+
+type DeliveryPrice struct {
+	DeliveryTime  uint64  `json:"deliveryTime"`
+	DeliveryPrice float64 `json:"deliveryPrice"`
+}
+
+type DeliveryPositionOpts struct {
+	MarginAsset string `url:"marginAsset,omitempty"`
+	Pair        string `url:"pair,omitempty"`
+}
+
+// DeliveryPosition represents a COIN-M position, which amount is in contracts
+type DeliveryPosition struct {
+	Symbol           string       `json:"symbol"`
+	PositionSide     PositionSide `json:"positionSide"`
+	PositionAmt      string       `json:"positionAmt"` // PositionAmt is the number of contracts, negative for short positions in one-way mode
+	EntryPrice       string       `json:"entryPrice"`
+	BreakEvenPrice   string       `json:"breakEvenPrice"`
+	MarkPrice        string       `json:"markPrice"`
+	UnrealizedProfit string       `json:"unRealizedProfit"`
+	LiquidationPrice string       `json:"liquidationPrice"`
+	Leverage         string       `json:"leverage"`
+	MaxQty           string       `json:"maxQty"` // MaxQty is the maximal number of contracts at this leverage
+	MarginType       string       `json:"marginType"`
+	IsolatedMargin   string       `json:"isolatedMargin"`
+	IsolatedWallet   string       `json:"isolatedWallet"`
+	IsAutoAddMargin  string       `json:"isAutoAddMargin"`
+	NotionalValue    string       `json:"notionalValue"` // NotionalValue is in margin asset
+	UpdateTime       uint64       `json:"updateTime"`
+}
+
+// DeliveryOrder represents a COIN-M order, as received when sending in, querying or canceling it
+type DeliveryOrder struct {
+	FuturesOrder
+	Pair    string `json:"pair"`
+	CumBase string `json:"cumBase"` // CumBase is the accumulated filled value in base asset
+}
+
+type DeliveryAccount struct {
+	CanDeposit  bool                       `json:"canDeposit"`
+	CanTrade    bool                       `json:"canTrade"`
+	CanWithdraw bool                       `json:"canWithdraw"`
+	FeeTier     int                        `json:"feeTier"`
+	UpdateTime  uint64                     `json:"updateTime"`
+	Assets      []*DeliveryAccountAsset    `json:"assets"`
+	Positions   []*DeliveryAccountPosition `json:"positions"`
+}
+
+type DeliveryAccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	UpdateTime             uint64 `json:"updateTime"`
+}
+
+type DeliveryAccountPosition struct {
+	Symbol                 string       `json:"symbol"`
+	PositionSide           PositionSide `json:"positionSide"`
+	PositionAmt            string       `json:"positionAmt"`
+	EntryPrice             string       `json:"entryPrice"`
+	BreakEvenPrice         string       `json:"breakEvenPrice"`
+	UnrealizedProfit       string       `json:"unrealizedProfit"`
+	InitialMargin          string       `json:"initialMargin"`
+	MaintMargin            string       `json:"maintMargin"`
+	PositionInitialMargin  string       `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string       `json:"openOrderInitialMargin"`
+	Leverage               string       `json:"leverage"`
+	Isolated               bool         `json:"isolated"`
+	MaxQty                 string       `json:"maxQty"`
+	UpdateTime             uint64       `json:"updateTime"`
+}
@@ -0,0 +1,375 @@
+package binance
+
+// FuturesOrderType represents the type of a futures order
+type FuturesOrderType string
+
+const (
+	FuturesOrderTypeLimit              FuturesOrderType = "LIMIT"
+	FuturesOrderTypeMarket             FuturesOrderType = "MARKET"
+	FuturesOrderTypeStop               FuturesOrderType = "STOP"
+	FuturesOrderTypeStopMarket         FuturesOrderType = "STOP_MARKET"
+	FuturesOrderTypeTakeProfit         FuturesOrderType = "TAKE_PROFIT"
+	FuturesOrderTypeTakeProfitMarket   FuturesOrderType = "TAKE_PROFIT_MARKET"
+	FuturesOrderTypeTrailingStopMarket FuturesOrderType = "TRAILING_STOP_MARKET"
+)
+
+// PositionSide represents the side of a futures position
+// Remark: In one-way mode, all positions are BOTH. LONG and SHORT are used in hedge mode only
+type PositionSide string
+
+const (
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+// WorkingType represents the price which triggers a futures stop order
+type WorkingType string
+
+const (
+	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
+	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
+)
+
+// ContractType represents the type of a futures contract
+type ContractType string
+
+const (
+	ContractTypePerpetual      ContractType = "PERPETUAL"
+	ContractTypeCurrentQuarter ContractType = "CURRENT_QUARTER"
+	ContractTypeNextQuarter    ContractType = "NEXT_QUARTER"
+)
+
+// MarginType represents the margin mode of a futures symbol
+type MarginType string
+
+const (
+	MarginTypeIsolated MarginType = "ISOLATED"
+	MarginTypeCrossed  MarginType = "CROSSED"
+)
+
+type FuturesExchangeInfo struct {
+	Timezone   string               `json:"timezone"`
+	ServerTime uint64               `json:"serverTime"`
+	Symbols    []*FuturesSymbolInfo `json:"symbols"`
+}
+
+type FuturesSymbolInfo struct {
+	Symbol            string             `json:"symbol"`
+	Pair              string             `json:"pair"`
+	ContractType      ContractType       `json:"contractType"`
+	DeliveryDate      uint64             `json:"deliveryDate"`
+	OnboardDate       uint64             `json:"onboardDate"`
+	Status            SymbolStatus       `json:"status"`
+	BaseAsset         string             `json:"baseAsset"`
+	QuoteAsset        string             `json:"quoteAsset"`
+	MarginAsset       string             `json:"marginAsset"`
+	PricePrecision    int                `json:"pricePrecision"`
+	QuantityPrecision int                `json:"quantityPrecision"`
+	LiquidationFee    string             `json:"liquidationFee"`
+	MarketTakeBound   string             `json:"marketTakeBound"` // MarketTakeBound is the maximal price deviation of market orders from the mark price
+	OrderTypes        []FuturesOrderType `json:"orderTypes"`
+	TimeInForce       []TimeInForce      `json:"timeInForce"`
+	Filters           []SymbolInfoFilter `json:"filters"`
+}
+
+type MarkPriceOpts struct {
+	Symbol string `url:"symbol"`
+}
+
+type MarkPrice struct {
+	Symbol               string `json:"symbol"`
+	Pair                 string `json:"pair"` // Pair is only set for COIN-M contracts
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"` // EstimatedSettlePrice is only meaningful in the last hour before the settlement
+	LastFundingRate      string `json:"lastFundingRate"`
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      uint64 `json:"nextFundingTime"`
+	Time                 uint64 `json:"time"`
+}
+
+// FundingRateOpts represents the opts used for querying the funding rate history
+// Remark: If StartTime and EndTime are not set, the most recent funding rates are returned
+type FundingRateOpts struct {
+	Symbol    string `url:"symbol,omitempty"`
+	StartTime uint64 `url:"startTime,omitempty"`
+	EndTime   uint64 `url:"endTime,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 100, max 1000
+}
+
+type FundingRate struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime uint64 `json:"fundingTime"`
+	MarkPrice   string `json:"markPrice"`
+}
+
+type FuturesPositionOpts struct {
+	Symbol string `url:"symbol,omitempty"` // Symbol, if not set, positions of all symbols are received
+}
+
+type FuturesPosition struct {
+	Symbol           string       `json:"symbol"`
+	PositionSide     PositionSide `json:"positionSide"`
+	PositionAmt      string       `json:"positionAmt"` // PositionAmt is negative for short positions in one-way mode
+	EntryPrice       string       `json:"entryPrice"`
+	BreakEvenPrice   string       `json:"breakEvenPrice"`
+	MarkPrice        string       `json:"markPrice"`
+	UnrealizedProfit string       `json:"unRealizedProfit"`
+	LiquidationPrice string       `json:"liquidationPrice"`
+	Leverage         string       `json:"leverage"`
+	MaxNotionalValue string       `json:"maxNotionalValue"`
+	MarginType       string       `json:"marginType"` // MarginType is either isolated or cross
+	IsolatedMargin   string       `json:"isolatedMargin"`
+	IsolatedWallet   string       `json:"isolatedWallet"`
+	IsAutoAddMargin  string       `json:"isAutoAddMargin"`
+	Notional         string       `json:"notional"`
+	UpdateTime       uint64       `json:"updateTime"`
+}
+
+type LeverageOpts struct {
+	Symbol   string `url:"symbol"`
+	Leverage int    `url:"leverage"` // Leverage is the initial leverage, from 1 to 125
+}
+
+type Leverage struct {
+	Symbol           string `json:"symbol"`
+	Leverage         int    `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"` // MaxNotionalValue is the maximal position notional at this leverage
+}
+
+type MarginTypeOpts struct {
+	Symbol     string     `url:"symbol"`
+	MarginType MarginType `url:"marginType"`
+}
+
+// FuturesNewOrderOpts represents the opts used for sending in a new futures order
+// Remark: ClosePosition can't be used along with Quantity or ReduceOnly
+type FuturesNewOrderOpts struct {
+	Symbol           string           `url:"symbol"`
+	Side             OrderSide        `url:"side"`
+	PositionSide     PositionSide     `url:"positionSide,omitempty"` // PositionSide is required in hedge mode
+	Type             FuturesOrderType `url:"type"`
+	TimeInForce      TimeInForce      `url:"timeInForce,omitempty"`
+	Quantity         string           `url:"quantity,omitempty"`
+	Price            string           `url:"price,omitempty"`
+	ReduceOnly       bool             `url:"reduceOnly,omitempty"` // ReduceOnly makes sure the order only reduces the position. Not allowed in hedge mode
+	NewClientOrderId string           `url:"newClientOrderId,omitempty"`
+	StopPrice        string           `url:"stopPrice,omitempty"`
+	ClosePosition    bool             `url:"closePosition,omitempty"` // ClosePosition closes the whole position once a STOP_MARKET or TAKE_PROFIT_MARKET order triggers
+	ActivationPrice  string           `url:"activationPrice,omitempty"`
+	CallbackRate     string           `url:"callbackRate,omitempty"` // CallbackRate is the TRAILING_STOP_MARKET callback in percents, from 0.1 to 10
+	WorkingType      WorkingType      `url:"workingType,omitempty"`
+	PriceProtect     bool             `url:"priceProtect,omitempty"`
+}
+
+// FuturesOrder represents a futures order, as received when sending in, querying or canceling it
+type FuturesOrder struct {
+	Symbol        string           `json:"symbol"`
+	OrderID       int64            `json:"orderId"`
+	ClientOrderID string           `json:"clientOrderId"`
+	Price         string           `json:"price"`
+	AvgPrice      string           `json:"avgPrice"`
+	OrigQty       string           `json:"origQty"`
+	ExecutedQty   string           `json:"executedQty"`
+	CumQuote      string           `json:"cumQuote"`
+	Status        OrderStatus      `json:"status"`
+	TimeInForce   TimeInForce      `json:"timeInForce"`
+	Type          FuturesOrderType `json:"type"`
+	OrigType      FuturesOrderType `json:"origType"`
+	Side          OrderSide        `json:"side"`
+	PositionSide  PositionSide     `json:"positionSide"`
+	StopPrice     string           `json:"stopPrice"`
+	ReduceOnly    bool             `json:"reduceOnly"`
+	ClosePosition bool             `json:"closePosition"`
+	ActivatePrice string           `json:"activatePrice"`
+	PriceRate     string           `json:"priceRate"`
+	WorkingType   WorkingType      `json:"workingType"`
+	PriceProtect  bool             `json:"priceProtect"`
+	Time          uint64           `json:"time"`
+	UpdateTime    uint64           `json:"updateTime"`
+}
+
+type FuturesQueryOrderOpts struct {
+	Symbol            string `url:"symbol"`
+	OrderID           int64  `url:"orderId,omitempty"`
+	OrigClientOrderId string `url:"origClientOrderId,omitempty"`
+}
+
+type FuturesOpenOrdersOpts struct {
+	Symbol string `url:"symbol,omitempty"` // Symbol, if not set, open orders of all symbols are received
+}
+
+type FuturesBalance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	MaxWithdrawAmount  string `json:"maxWithdrawAmount"`
+	MarginAvailable    bool   `json:"marginAvailable"` // MarginAvailable indicates whether the asset can be used as margin in multi-assets mode
+	UpdateTime         uint64 `json:"updateTime"`
+}
+
+// FuturesUserDataEvent is a futures user data update, which is one of *FuturesOrderUpdate, *FuturesAccountUpdate,
+// *ListenKeyExpiredUpdate or *UnknownUserUpdate
+type FuturesUserDataEvent interface {
+	futuresUserDataEvent()
+}
+
+func (*FuturesOrderUpdate) futuresUserDataEvent()     {}
+func (*FuturesAccountUpdate) futuresUserDataEvent()   {}
+func (*ListenKeyExpiredUpdate) futuresUserDataEvent() {}
+func (*UnknownUserUpdate) futuresUserDataEvent()      {}
+
+// FuturesOrderUpdate represents the incoming messages for futures order updates
+type FuturesOrderUpdate struct {
+	EventType       UpdateType          `json:"e"` // EventType represents the update type
+	Time            uint64              `json:"E"` // Time represents the event time
+	TransactionTime uint64              `json:"T"`
+	Order           *FuturesOrderDetail `json:"o"`
+}
+
+// FuturesOrderDetail represents the order of a futures order update
+type FuturesOrderDetail struct {
+	Symbol              string           `json:"s"`
+	ClientOrderID       string           `json:"c"`
+	Side                OrderSide        `json:"S"`
+	Type                FuturesOrderType `json:"o"`
+	TimeInForce         TimeInForce      `json:"f"`
+	OrigQty             string           `json:"q"`
+	Price               string           `json:"p"`
+	AvgPrice            string           `json:"ap"`
+	StopPrice           string           `json:"sp"`
+	ExecutionType       OrderStatus      `json:"x"` // ExecutionType represents the execution type for the order
+	Status              OrderStatus      `json:"X"`
+	OrderID             int64            `json:"i"`
+	LastFilledQty       string           `json:"l"`
+	FilledQty           string           `json:"z"` // FilledQty is the accumulated filled quantity
+	LastFilledPrice     string           `json:"L"`
+	CommissionAsset     string           `json:"N"`
+	Commission          string           `json:"n"`
+	TradeTime           uint64           `json:"T"`
+	TradeID             int64            `json:"t"`
+	BidsNotional        string           `json:"b"`
+	AsksNotional        string           `json:"a"`
+	Maker               bool             `json:"m"`
+	ReduceOnly          bool             `json:"R"`
+	WorkingType         WorkingType      `json:"wt"`
+	OrigType            FuturesOrderType `json:"ot"`
+	PositionSide        PositionSide     `json:"ps"`
+	ClosePosition       bool             `json:"cp"`
+	ActivationPrice     string           `json:"AP"`
+	CallbackRate        string           `json:"cr"`
+	PriceProtect        bool             `json:"pP"`
+	RealizedProfit      string           `json:"rp"`
+	SelfTradePrevention string           `json:"V"`
+	PriceMatch          string           `json:"pm"`
+	GoodTillDate        uint64           `json:"gtd"`
+}
+
+// FuturesAccountUpdate represents the incoming messages for futures balances and positions updates
+type FuturesAccountUpdate struct {
+	EventType       UpdateType            `json:"e"` // EventType represents the update type
+	Time            uint64                `json:"E"` // Time represents the event time
+	TransactionTime uint64                `json:"T"`
+	Account         *FuturesAccountDetail `json:"a"`
+}
+
+// FuturesAccountDetail represents the changed balances and positions of a futures account update
+type FuturesAccountDetail struct {
+	Reason    string                   `json:"m"` // Reason is the cause of the update, e.g. ORDER, FUNDING_FEE or DEPOSIT
+	Balances  []*FuturesBalanceUpdate  `json:"B"`
+	Positions []*FuturesPositionUpdate `json:"P"`
+}
+
+type FuturesBalanceUpdate struct {
+	Asset              string `json:"a"`
+	WalletBalance      string `json:"wb"`
+	CrossWalletBalance string `json:"cw"`
+	BalanceChange      string `json:"bc"` // BalanceChange is the change except PnL and commission
+}
+
+type FuturesPositionUpdate struct {
+	Symbol              string       `json:"s"`
+	PositionAmt         string       `json:"pa"`
+	EntryPrice          string       `json:"ep"`
+	BreakEvenPrice      string       `json:"bep"`
+	AccumulatedRealized string       `json:"cr"`
+	UnrealizedProfit    string       `json:"up"`
+	MarginType          string       `json:"mt"` // MarginType is either isolated or cross
+	IsolatedWallet      string       `json:"iw"`
+	PositionSide        PositionSide `json:"ps"`
+}
+
+// MarkPriceUpdate represents the incoming messages for futures mark price websocket updates
+type MarkPriceUpdate struct {
+	EventType            UpdateType `json:"e"` // EventType represents the update type
+	Time                 uint64     `json:"E"` // Time represents the event time
+	Symbol               string     `json:"s"` // Symbol represents the symbol related to the update
+	MarkPrice            string     `json:"p"`
+	IndexPrice           string     `json:"i"`
+	EstimatedSettlePrice string     `json:"P"` // EstimatedSettlePrice is only meaningful in the last hour before the settlement
+	FundingRate          string     `json:"r"` // FundingRate is empty for delivery contracts
+	NextFundingTime      uint64     `json:"T"`
+}
+
+// LiquidationUpdate represents the incoming messages for futures liquidation orders websocket updates
+// Remark: Only the latest liquidation order of a symbol within each second is pushed
+type LiquidationUpdate struct {
+	EventType UpdateType        `json:"e"` // EventType represents the update type
+	Time      uint64            `json:"E"` // Time represents the event time
+	Order     *LiquidationOrder `json:"o"` // Order is the liquidation order
+}
+
+type LiquidationOrder struct {
+	Symbol        string           `json:"s"`
+	Side          OrderSide        `json:"S"`
+	Type          FuturesOrderType `json:"o"`
+	TimeInForce   TimeInForce      `json:"f"`
+	OrigQty       string           `json:"q"`
+	Price         string           `json:"p"`
+	AvgPrice      string           `json:"ap"`
+	Status        OrderStatus      `json:"X"`
+	LastFilledQty string           `json:"l"`
+	FilledQty     string           `json:"z"` // FilledQty is the accumulated filled quantity
+	TradeTime     uint64           `json:"T"`
+}
+
+// ContinuousKlineUpdate represents the incoming messages for futures continuous contract klines websocket updates
+type ContinuousKlineUpdate struct {
+	EventType    UpdateType   `json:"e"`  // EventType represents the update type
+	Time         uint64       `json:"E"`  // Time represents the event time
+	Pair         string       `json:"ps"` // Pair represents the pair related to the update
+	ContractType ContractType `json:"ct"`
+	Kline        StreamKline  `json:"k"` // Kline is the kline update
+}
+
+// IndexPriceKlineUpdate represents the incoming messages for futures index price klines websocket updates
+// Remark: Index price klines carry no trades, hence their volumes and trade IDs are zero
+type IndexPriceKlineUpdate struct {
+	EventType UpdateType  `json:"e"`  // EventType represents the update type
+	Time      uint64      `json:"E"`  // Time represents the event time
+	Pair      string      `json:"ps"` // Pair represents the pair related to the update
+	Kline     StreamKline `json:"k"`  // Kline is the kline update
+}
+
+// CompositeIndexUpdate represents the incoming messages for futures composite index websocket updates
+type CompositeIndexUpdate struct {
+	EventType  UpdateType                 `json:"e"` // EventType represents the update type
+	Time       uint64                     `json:"E"` // Time represents the event time
+	Symbol     string                     `json:"s"` // Symbol represents the symbol related to the update
+	Price      string                     `json:"p"`
+	BaseAsset  string                     `json:"C"`
+	Components []*CompositeIndexComponent `json:"c"` // Components are the assets composing the index
+}
+
+type CompositeIndexComponent struct {
+	BaseAsset     string `json:"b"`
+	QuoteAsset    string `json:"q"`
+	WeightQty     string `json:"w"`
+	WeightPercent string `json:"W"`
+	IndexPrice    string `json:"i"`
+}
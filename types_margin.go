@@ -0,0 +1,200 @@
+package binance
+
+// MarginIsolated indicates whether a margin call targets an isolated margin account rather than the cross margin account
+type MarginIsolated string
+
+const (
+	MarginIsolatedTrue  MarginIsolated = "TRUE"
+	MarginIsolatedFalse MarginIsolated = "FALSE"
+)
+
+// SideEffectType represents the borrowing and repaying done along with a margin order
+type SideEffectType string
+
+const (
+	SideEffectTypeNoSideEffect    SideEffectType = "NO_SIDE_EFFECT"    // SideEffectTypeNoSideEffect places the order without borrowing or repaying
+	SideEffectTypeMarginBuy       SideEffectType = "MARGIN_BUY"        // SideEffectTypeMarginBuy borrows the funds the order requires
+	SideEffectTypeAutoRepay       SideEffectType = "AUTO_REPAY"        // SideEffectTypeAutoRepay repays debt with the order proceeds
+	SideEffectTypeAutoBorrowRepay SideEffectType = "AUTO_BORROW_REPAY" // SideEffectTypeAutoBorrowRepay borrows the funds the order requires and repays debt with its proceeds
+)
+
+// MarginLoanType represents the direction of a margin loan operation
+type MarginLoanType string
+
+const (
+	MarginLoanTypeBorrow MarginLoanType = "BORROW"
+	MarginLoanTypeRepay  MarginLoanType = "REPAY"
+)
+
+// MarginAccount represents the cross margin account
+type MarginAccount struct {
+	BorrowEnabled       bool               `json:"borrowEnabled"`
+	TradeEnabled        bool               `json:"tradeEnabled"`
+	TransferEnabled     bool               `json:"transferEnabled"`
+	MarginLevel         string             `json:"marginLevel"`
+	TotalAssetOfBTC     string             `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBTC string             `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBTC  string             `json:"totalNetAssetOfBtc"`
+	UserAssets          []*MarginUserAsset `json:"userAssets"`
+}
+
+type MarginUserAsset struct {
+	Asset    string `json:"asset"`
+	Free     string `json:"free"`
+	Locked   string `json:"locked"`
+	Borrowed string `json:"borrowed"`
+	Interest string `json:"interest"`
+	NetAsset string `json:"netAsset"` // NetAsset is the balance after deducting the borrowed amount and interest
+}
+
+// IsolatedMarginAccountOpts represents the opts used for querying isolated margin accounts
+type IsolatedMarginAccountOpts struct {
+	Symbols string `url:"symbols,omitempty"` // Symbols, if set, filters the comma separated symbols. Max 5
+}
+
+// IsolatedMarginAccount represents the isolated margin accounts, with their totals in BTC
+type IsolatedMarginAccount struct {
+	TotalAssetOfBTC     string                         `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBTC string                         `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBTC  string                         `json:"totalNetAssetOfBtc"`
+	Assets              []*IsolatedMarginAccountSymbol `json:"assets"`
+}
+
+// IsolatedMarginAccountSymbol represents the isolated margin account of a symbol
+type IsolatedMarginAccountSymbol struct {
+	Symbol            string               `json:"symbol"`
+	BaseAsset         *IsolatedMarginAsset `json:"baseAsset"`
+	QuoteAsset        *IsolatedMarginAsset `json:"quoteAsset"`
+	IsolatedCreated   bool                 `json:"isolatedCreated"`
+	Enabled           bool                 `json:"enabled"`
+	TradeEnabled      bool                 `json:"tradeEnabled"`
+	MarginLevel       string               `json:"marginLevel"`
+	MarginLevelStatus string               `json:"marginLevelStatus"` // MarginLevelStatus is one of EXCESSIVE, NORMAL, MARGIN_CALL, PRE_LIQUIDATION or FORCE_LIQUIDATION
+	MarginRatio       string               `json:"marginRatio"`
+	IndexPrice        string               `json:"indexPrice"`
+	LiquidatePrice    string               `json:"liquidatePrice"`
+	LiquidateRate     string               `json:"liquidateRate"`
+}
+
+type IsolatedMarginAsset struct {
+	Asset         string `json:"asset"`
+	BorrowEnabled bool   `json:"borrowEnabled"`
+	RepayEnabled  bool   `json:"repayEnabled"`
+	Free          string `json:"free"`
+	Locked        string `json:"locked"`
+	Borrowed      string `json:"borrowed"`
+	Interest      string `json:"interest"`
+	NetAsset      string `json:"netAsset"`
+	NetAssetOfBTC string `json:"netAssetOfBtc"`
+	TotalAsset    string `json:"totalAsset"`
+}
+
+// MarginLoanOpts represents the opts used for borrowing or repaying margin loans
+type MarginLoanOpts struct {
+	Asset      string         `url:"asset"`
+	IsIsolated MarginIsolated `url:"isIsolated,omitempty"`
+	Symbol     string         `url:"symbol,omitempty"` // Symbol is required for isolated margin loans
+	Amount     string         `url:"amount"`
+}
+
+// marginLoanOpts are used to borrow or repay through the single borrow-repay endpoint
+type marginLoanOpts struct {
+	MarginLoanOpts
+	Type MarginLoanType `url:"type"`
+}
+
+type MarginLoan struct {
+	TranID int64 `json:"tranId"`
+}
+
+// MarginMaxOpts represents the opts used for querying the maximal amount that can be borrowed or transferred out
+type MarginMaxOpts struct {
+	Asset          string `url:"asset"`
+	IsolatedSymbol string `url:"isolatedSymbol,omitempty"` // IsolatedSymbol, if set, queries the isolated margin account of the symbol
+}
+
+type MarginMaxBorrowable struct {
+	Amount      string `json:"amount"`
+	BorrowLimit string `json:"borrowLimit"` // BorrowLimit is the borrowing limit of the account VIP level
+}
+
+type MarginMaxTransferable struct {
+	Amount string `json:"amount"`
+}
+
+// MarginNewOrderOpts represents the opts used for sending in a new margin order
+type MarginNewOrderOpts struct {
+	NewOrderOpts
+	IsIsolated        MarginIsolated `url:"isIsolated,omitempty"`
+	SideEffectType    SideEffectType `url:"sideEffectType,omitempty"`    // SideEffectType, if not set, is NO_SIDE_EFFECT
+	AutoRepayAtCancel *bool          `url:"autoRepayAtCancel,omitempty"` // AutoRepayAtCancel, if set to false, keeps the debt borrowed by a canceled MARGIN_BUY or AUTO_BORROW_REPAY order
+}
+
+type MarginNewOrder struct {
+	Symbol                string `json:"symbol"`
+	OrderID               int    `json:"orderId"`
+	ClientOrderID         string `json:"clientOrderId"`
+	TransactTime          uint64 `json:"transactTime"`
+	IsIsolated            bool   `json:"isIsolated"`
+	MarginBuyBorrowAsset  string `json:"marginBuyBorrowAsset"`  // MarginBuyBorrowAsset is the asset borrowed by the order, if any
+	MarginBuyBorrowAmount string `json:"marginBuyBorrowAmount"` // MarginBuyBorrowAmount is the amount borrowed by the order, if any
+}
+
+type MarginQueryOrderOpts struct {
+	Symbol            string         `url:"symbol"`
+	IsIsolated        MarginIsolated `url:"isIsolated,omitempty"`
+	OrderID           int            `url:"orderId,omitempty"`
+	OrigClientOrderId string         `url:"origClientOrderId,omitempty"`
+}
+
+type MarginQueryOrder struct {
+	QueryOrder
+	IsIsolated bool `json:"isIsolated"`
+}
+
+type MarginCancelOrderOpts struct {
+	Symbol            string         `url:"symbol"`
+	IsIsolated        MarginIsolated `url:"isIsolated,omitempty"`
+	OrderID           int            `url:"orderId,omitempty"`
+	OrigClientOrderId string         `url:"origClientOrderId,omitempty"`
+	NewClientOrderId  string         `url:"newClientOrderId,omitempty"`
+}
+
+type MarginCancelOrder struct {
+	CancelOrder
+	IsIsolated bool `json:"isIsolated"`
+}
+
+// MarginInterestHistoryOpts represents the opts used for querying margin interests
+// Remark: Only interests of the last 30 days are received if StartTime is not set
+type MarginInterestHistoryOpts struct {
+	Asset          string `url:"asset,omitempty"`
+	IsolatedSymbol string `url:"isolatedSymbol,omitempty"` // IsolatedSymbol, if set, filters interests of the isolated margin account of the symbol
+	StartTime      uint64 `url:"startTime,omitempty"`
+	EndTime        uint64 `url:"endTime,omitempty"`
+	Current        int    `url:"current,omitempty"` // Current is the page to receive, starting from 1
+	Size           int    `url:"size,omitempty"`    // Size is the number of elements per page. Default 10, max 100
+}
+
+type MarginInterestHistory struct {
+	Total int               `json:"total"`
+	Rows  []*MarginInterest `json:"rows"`
+}
+
+type MarginInterest struct {
+	TxID                int64  `json:"txId"`
+	Asset               string `json:"asset"`
+	RawAsset            string `json:"rawAsset"` // RawAsset is the asset the interest was charged for, if charged in BNB
+	Principal           string `json:"principal"`
+	Interest            string `json:"interest"`
+	InterestRate        string `json:"interestRate"`
+	InterestAccuredTime uint64 `json:"interestAccuredTime"`
+	Type                string `json:"type"` // Type is one of PERIODIC, ON_BORROW, PERIODIC_CONVERTED or ON_BORROW_CONVERTED
+	IsolatedSymbol      string `json:"isolatedSymbol"`
+}
+
+// IsolatedDatastream identifies the datastream key of an isolated margin account
+type IsolatedDatastream struct {
+	Symbol    string `url:"symbol"`
+	ListenKey string `url:"listenKey,omitempty"`
+}
@@ -0,0 +1,135 @@
+package binance
+
+// SubAccountType represents the wallet of a master or sub-account that funds are transferred from or to
+type SubAccountType string
+
+const (
+	SubAccountTypeSpot           SubAccountType = "SPOT"
+	SubAccountTypeUSDTFutures    SubAccountType = "USDT_FUTURE"
+	SubAccountTypeCoinFutures    SubAccountType = "COIN_FUTURE"
+	SubAccountTypeMargin         SubAccountType = "MARGIN"
+	SubAccountTypeIsolatedMargin SubAccountType = "ISOLATED_MARGIN"
+)
+
+// SubAccountsOpts represents the opts used for listing sub-accounts
+type SubAccountsOpts struct {
+	Email    string `url:"email,omitempty"`    // Email, if set, filters the sub-account of the given email
+	IsFreeze *bool  `url:"isFreeze,omitempty"` // IsFreeze, if set, filters frozen or active sub-accounts
+	Page     int    `url:"page,omitempty"`     // Page is the page to receive, starting from 1
+	Limit    int    `url:"limit,omitempty"`    // Limit is the number of elements per page. Default 1, max 200
+}
+
+type SubAccounts struct {
+	SubAccounts []*SubAccount `json:"subAccounts"`
+}
+
+type SubAccount struct {
+	Email                       string `json:"email"`
+	IsFreeze                    bool   `json:"isFreeze"`
+	CreateTime                  uint64 `json:"createTime"`
+	IsManagedSubAccount         bool   `json:"isManagedSubAccount"`
+	IsAssetManagementSubAccount bool   `json:"isAssetManagementSubAccount"`
+}
+
+type SubAccountAssetsOpts struct {
+	Email string `url:"email"`
+}
+
+type SubAccountAssets struct {
+	Balances []*Balance `json:"balances"`
+}
+
+// SubAccountTransferOpts represents the opts used for transferring funds from or to sub-accounts
+// Remark: If FromEmail is not set, funds are transferred from the master account, and if ToEmail is not set, funds are
+// transferred to the master account. Either of them must be set
+type SubAccountTransferOpts struct {
+	FromEmail       string         `url:"fromEmail,omitempty"`
+	ToEmail         string         `url:"toEmail,omitempty"`
+	FromAccountType SubAccountType `url:"fromAccountType"`
+	ToAccountType   SubAccountType `url:"toAccountType"`
+	ClientTranID    string         `url:"clientTranId,omitempty"` // ClientTranID is a client ID for the transfer, which must be unique
+	Symbol          string         `url:"symbol,omitempty"`       // Symbol is required when transferring from or to an isolated margin account
+	Asset           string         `url:"asset"`
+	Amount          string         `url:"amount"`
+}
+
+type SubAccountTransfer struct {
+	TranID       int64  `json:"tranId"`
+	ClientTranID string `json:"clientTranId"`
+}
+
+// SubAccountTransferHistoryOpts represents the opts used for querying sub-accounts transfers
+// Remark: Only transfers of the last 30 days are received if StartTime and EndTime are not set
+type SubAccountTransferHistoryOpts struct {
+	FromEmail    string `url:"fromEmail,omitempty"`
+	ToEmail      string `url:"toEmail,omitempty"`
+	ClientTranID string `url:"clientTranId,omitempty"`
+	StartTime    uint64 `url:"startTime,omitempty"`
+	EndTime      uint64 `url:"endTime,omitempty"`
+	Page         int    `url:"page,omitempty"`  // Page is the page to receive, starting from 1
+	Limit        int    `url:"limit,omitempty"` // Limit is the number of elements per page. Default 500, max 500
+}
+
+type SubAccountTransferHistory struct {
+	TotalCount int                              `json:"totalCount"` // TotalCount is the number of transfers matching the query across all pages
+	Result     []*SubAccountTransferHistoryElem `json:"result"`
+}
+
+type SubAccountTransferHistoryElem struct {
+	TranID          int64          `json:"tranId"`
+	ClientTranID    string         `json:"clientTranId"`
+	FromEmail       string         `json:"fromEmail"`
+	ToEmail         string         `json:"toEmail"`
+	FromAccountType SubAccountType `json:"fromAccountType"`
+	ToAccountType   SubAccountType `json:"toAccountType"`
+	Asset           string         `json:"asset"`
+	Amount          string         `json:"amount"`
+	Status          string         `json:"status"`
+	CreateTimeStamp uint64         `json:"createTimeStamp"`
+}
+
+// SubAccountSummaryOpts represents the opts used for querying summaries of all sub-accounts
+type SubAccountSummaryOpts struct {
+	Page  int `url:"page,omitempty"`  // Page is the page to receive, starting from 1
+	Limit int `url:"limit,omitempty"` // Limit is the number of elements per page. Default 10, max 20
+}
+
+// SubAccountFuturesSummary represents the USD-M futures accounts of all sub-accounts, with their totals
+type SubAccountFuturesSummary struct {
+	Asset                       string                      `json:"asset"`
+	TotalInitialMargin          string                      `json:"totalInitialMargin"`
+	TotalMaintenanceMargin      string                      `json:"totalMaintenanceMargin"`
+	TotalMarginBalance          string                      `json:"totalMarginBalance"`
+	TotalOpenOrderInitialMargin string                      `json:"totalOpenOrderInitialMargin"`
+	TotalPositionInitialMargin  string                      `json:"totalPositionInitialMargin"`
+	TotalUnrealizedProfit       string                      `json:"totalUnrealizedProfit"`
+	TotalWalletBalance          string                      `json:"totalWalletBalance"`
+	SubAccounts                 []*SubAccountFuturesAccount `json:"subAccountList"`
+}
+
+type SubAccountFuturesAccount struct {
+	Email                       string `json:"email"`
+	Asset                       string `json:"asset"`
+	TotalInitialMargin          string `json:"totalInitialMargin"`
+	TotalMaintenanceMargin      string `json:"totalMaintenanceMargin"`
+	TotalMarginBalance          string `json:"totalMarginBalance"`
+	TotalOpenOrderInitialMargin string `json:"totalOpenOrderInitialMargin"`
+	TotalPositionInitialMargin  string `json:"totalPositionInitialMargin"`
+	TotalUnrealizedProfit       string `json:"totalUnrealizedProfit"`
+	TotalWalletBalance          string `json:"totalWalletBalance"`
+}
+
+// SubAccountMarginSummary represents the margin accounts of all sub-accounts, with their totals in BTC
+type SubAccountMarginSummary struct {
+	TotalAssetOfBTC     string                     `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBTC string                     `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBTC  string                     `json:"totalNetAssetOfBtc"`
+	SubAccounts         []*SubAccountMarginAccount `json:"subAccountList"`
+}
+
+type SubAccountMarginAccount struct {
+	Email               string `json:"email"`
+	TotalAssetOfBTC     string `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBTC string `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBTC  string `json:"totalNetAssetOfBtc"`
+}
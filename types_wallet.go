@@ -0,0 +1,343 @@
+package binance
+
+// DepositStatus represents the status of a deposit
+type DepositStatus int
+
+const (
+	DepositStatusPending         DepositStatus = 0 // DepositStatusPending is a deposit waiting for confirmations
+	DepositStatusSuccess         DepositStatus = 1 // DepositStatusSuccess is a credited deposit
+	DepositStatusRejected        DepositStatus = 2 // DepositStatusRejected is a rejected deposit
+	DepositStatusCreditedLocked  DepositStatus = 6 // DepositStatusCreditedLocked is a credited deposit which can't be withdrawn yet
+	DepositStatusWrongDeposit    DepositStatus = 7 // DepositStatusWrongDeposit is a deposit to a wrong address or with a wrong tag
+	DepositStatusWaitingApproval DepositStatus = 8 // DepositStatusWaitingApproval is a deposit waiting for user confirmation
+)
+
+// WithdrawStatus represents the status of a withdrawal
+type WithdrawStatus int
+
+const (
+	WithdrawStatusEmailSent        WithdrawStatus = 0
+	WithdrawStatusCanceled         WithdrawStatus = 1
+	WithdrawStatusAwaitingApproval WithdrawStatus = 2
+	WithdrawStatusRejected         WithdrawStatus = 3
+	WithdrawStatusProcessing       WithdrawStatus = 4
+	WithdrawStatusFailure          WithdrawStatus = 5
+	WithdrawStatusCompleted        WithdrawStatus = 6
+)
+
+// WalletType represents the wallet funds are withdrawn from
+type WalletType int
+
+const (
+	WalletTypeSpot    WalletType = 0
+	WalletTypeFunding WalletType = 1
+)
+
+// CoinInfo represents the configuration of a coin and the account balances of it
+type CoinInfo struct {
+	Coin              string         `json:"coin"`
+	Name              string         `json:"name"`
+	DepositAllEnable  bool           `json:"depositAllEnable"`  // DepositAllEnable indicates whether deposits are enabled on any network
+	WithdrawAllEnable bool           `json:"withdrawAllEnable"` // WithdrawAllEnable indicates whether withdrawals are enabled on any network
+	Free              string         `json:"free"`
+	Locked            string         `json:"locked"`
+	Freeze            string         `json:"freeze"`
+	Withdrawing       string         `json:"withdrawing"`
+	Ipoing            string         `json:"ipoing"`
+	Ipoable           string         `json:"ipoable"`
+	Storage           string         `json:"storage"`
+	IsLegalMoney      bool           `json:"isLegalMoney"`
+	Trading           bool           `json:"trading"`
+	Networks          []*NetworkInfo `json:"networkList"` // Networks are the networks the coin can be deposited and withdrawn on
+}
+
+// NetworkInfo represents the configuration of a coin on a specific network
+type NetworkInfo struct {
+	Network                 string `json:"network"`
+	Coin                    string `json:"coin"`
+	Name                    string `json:"name"`
+	IsDefault               bool   `json:"isDefault"`
+	DepositEnable           bool   `json:"depositEnable"`
+	WithdrawEnable          bool   `json:"withdrawEnable"`
+	DepositDesc             string `json:"depositDesc"`  // DepositDesc is shown when deposits are disabled
+	WithdrawDesc            string `json:"withdrawDesc"` // WithdrawDesc is shown when withdrawals are disabled
+	SpecialTips             string `json:"specialTips"`
+	AddressRegex            string `json:"addressRegex"`
+	MemoRegex               string `json:"memoRegex"`
+	WithdrawFee             string `json:"withdrawFee"`
+	WithdrawMin             string `json:"withdrawMin"`
+	WithdrawMax             string `json:"withdrawMax"`
+	WithdrawIntegerMultiple string `json:"withdrawIntegerMultiple"` // WithdrawIntegerMultiple is the precision withdrawn amounts must be a multiple of
+	DepositDust             string `json:"depositDust"`             // DepositDust is the minimal amount a deposit is credited for
+	MinConfirm              int    `json:"minConfirm"`              // MinConfirm is the number of confirmations required for a deposit to be credited
+	UnlockConfirm           int    `json:"unLockConfirm"`           // UnlockConfirm is the number of confirmations required for a deposit to be withdrawable
+	SameAddress             bool   `json:"sameAddress"`             // SameAddress indicates whether deposits to this network require a tag
+	Busy                    bool   `json:"busy"`
+	EstimatedArrivalTime    uint64 `json:"estimatedArrivalTime"`
+	ContractAddress         string `json:"contractAddress"`
+	ContractAddressURL      string `json:"contractAddressUrl"`
+}
+
+type DepositAddressOpts struct {
+	Coin    string `url:"coin"`
+	Network string `url:"network,omitempty"` // Network, if not set, the default network of the coin is used
+	Amount  string `url:"amount,omitempty"`  // Amount is required by some networks for generating the address
+}
+
+type DepositAddress struct {
+	Coin    string `json:"coin"`
+	Address string `json:"address"`
+	Tag     string `json:"tag"` // Tag is the address tag or memo, which must be attached to deposits if set
+	URL     string `json:"url"`
+}
+
+// DepositHistoryOpts represents the opts used for querying deposits
+// Remark: The distance between StartTime and EndTime must be less than 90 days
+type DepositHistoryOpts struct {
+	Coin      string         `url:"coin,omitempty"`
+	Status    *DepositStatus `url:"status,omitempty"` // Status, if set, filters deposits of the given status
+	TxID      string         `url:"txId,omitempty"`
+	StartTime uint64         `url:"startTime,omitempty"`
+	EndTime   uint64         `url:"endTime,omitempty"`
+	Offset    int            `url:"offset,omitempty"`
+	Limit     int            `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Max 1000
+}
+
+type Deposit struct {
+	ID            string        `json:"id"`
+	Coin          string        `json:"coin"`
+	Amount        string        `json:"amount"`
+	Network       string        `json:"network"`
+	Status        DepositStatus `json:"status"`
+	Address       string        `json:"address"`
+	AddressTag    string        `json:"addressTag"`
+	TxID          string        `json:"txId"`
+	InsertTime    uint64        `json:"insertTime"`
+	CompleteTime  uint64        `json:"completeTime"`
+	TransferType  int           `json:"transferType"` // TransferType is 1 for internal transfers and 0 for external
+	ConfirmTimes  string        `json:"confirmTimes"` // ConfirmTimes is the confirmations progress, e.g. "1/1"
+	UnlockConfirm int           `json:"unlockConfirm"`
+	WalletType    WalletType    `json:"walletType"`
+}
+
+// WithdrawHistoryOpts represents the opts used for querying withdrawals
+// Remark: The distance between StartTime and EndTime must be less than 90 days
+type WithdrawHistoryOpts struct {
+	Coin            string          `url:"coin,omitempty"`
+	WithdrawOrderID string          `url:"withdrawOrderId,omitempty"` // WithdrawOrderID, if set, filters the withdrawal of the given client ID
+	Status          *WithdrawStatus `url:"status,omitempty"`          // Status, if set, filters withdrawals of the given status
+	IDList          string          `url:"idList,omitempty"`          // IDList, if set, filters withdrawals of the given comma separated IDs
+	StartTime       uint64          `url:"startTime,omitempty"`
+	EndTime         uint64          `url:"endTime,omitempty"`
+	Offset          int             `url:"offset,omitempty"`
+	Limit           int             `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Max 1000
+}
+
+type Withdrawal struct {
+	ID              string         `json:"id"`
+	WithdrawOrderID string         `json:"withdrawOrderId"`
+	Coin            string         `json:"coin"`
+	Amount          string         `json:"amount"`
+	TransactionFee  string         `json:"transactionFee"`
+	Network         string         `json:"network"`
+	Status          WithdrawStatus `json:"status"`
+	Address         string         `json:"address"`
+	AddressTag      string         `json:"addressTag"`
+	TxID            string         `json:"txId"`
+	ApplyTime       string         `json:"applyTime"`    // ApplyTime is the UTC request time, e.g. "2019-10-12 11:12:02"
+	CompleteTime    string         `json:"completeTime"` // CompleteTime is the UTC completion time, e.g. "2019-10-12 11:15:02"
+	TransferType    int            `json:"transferType"` // TransferType is 1 for internal transfers and 0 for external
+	Info            string         `json:"info"`         // Info is the failure reason, if failed
+	ConfirmNo       int            `json:"confirmNo"`
+	WalletType      WalletType     `json:"walletType"`
+	TxKey           string         `json:"txKey"`
+}
+
+// WithdrawOpts represents the opts used for submitting a withdrawal
+type WithdrawOpts struct {
+	Coin               string      `url:"coin"`
+	WithdrawOrderID    string      `url:"withdrawOrderId,omitempty"` // WithdrawOrderID is a client ID for the withdrawal
+	Network            string      `url:"network,omitempty"`         // Network, if not set, the default network of the coin is used
+	Address            string      `url:"address"`
+	AddressTag         string      `url:"addressTag,omitempty"` // AddressTag is the secondary address identifier, e.g. memo
+	Amount             string      `url:"amount"`
+	TransactionFeeFlag bool        `url:"transactionFeeFlag,omitempty"` // TransactionFeeFlag, if set, deducts the fee from the amount for internal transfers
+	Name               string      `url:"name,omitempty"`               // Name is the address description
+	WalletType         *WalletType `url:"walletType,omitempty"`         // WalletType, if not set, the spot wallet is used
+}
+
+type Withdraw struct {
+	ID string `json:"id"`
+}
+
+// TransferType represents the source and destination wallets of a universal transfer
+type TransferType string
+
+const (
+	TransferTypeMainToFunding        TransferType = "MAIN_FUNDING"
+	TransferTypeMainToMargin         TransferType = "MAIN_MARGIN"
+	TransferTypeMainToIsolatedMargin TransferType = "MAIN_ISOLATED_MARGIN"
+	TransferTypeMainToUMFutures      TransferType = "MAIN_UMFUTURE"
+	TransferTypeMainToCMFutures      TransferType = "MAIN_CMFUTURE"
+	TransferTypeFundingToMain        TransferType = "FUNDING_MAIN"
+	TransferTypeFundingToMargin      TransferType = "FUNDING_MARGIN"
+	TransferTypeFundingToUMFutures   TransferType = "FUNDING_UMFUTURE"
+	TransferTypeFundingToCMFutures   TransferType = "FUNDING_CMFUTURE"
+	TransferTypeMarginToMain         TransferType = "MARGIN_MAIN"
+	TransferTypeMarginToFunding      TransferType = "MARGIN_FUNDING"
+	TransferTypeMarginToIsolated     TransferType = "MARGIN_ISOLATEDMARGIN"
+	TransferTypeMarginToUMFutures    TransferType = "MARGIN_UMFUTURE"
+	TransferTypeMarginToCMFutures    TransferType = "MARGIN_CMFUTURE"
+	TransferTypeIsolatedMarginToMain TransferType = "ISOLATED_MARGIN_MAIN"
+	TransferTypeIsolatedToMargin     TransferType = "ISOLATEDMARGIN_MARGIN"
+	TransferTypeIsolatedToIsolated   TransferType = "ISOLATEDMARGIN_ISOLATEDMARGIN"
+	TransferTypeUMFuturesToMain      TransferType = "UMFUTURE_MAIN"
+	TransferTypeUMFuturesToFunding   TransferType = "UMFUTURE_FUNDING"
+	TransferTypeUMFuturesToMargin    TransferType = "UMFUTURE_MARGIN"
+	TransferTypeCMFuturesToMain      TransferType = "CMFUTURE_MAIN"
+	TransferTypeCMFuturesToFunding   TransferType = "CMFUTURE_FUNDING"
+	TransferTypeCMFuturesToMargin    TransferType = "CMFUTURE_MARGIN"
+)
+
+// TransferStatus represents the status of a universal transfer
+type TransferStatus string
+
+const (
+	TransferStatusPending   TransferStatus = "PENDING"
+	TransferStatusConfirmed TransferStatus = "CONFIRMED"
+	TransferStatusFailed    TransferStatus = "FAILED"
+)
+
+// TransferOpts represents the opts used for transferring funds between the account wallets
+type TransferOpts struct {
+	Type       TransferType `url:"type"`
+	Asset      string       `url:"asset"`
+	Amount     string       `url:"amount"`
+	FromSymbol string       `url:"fromSymbol,omitempty"` // FromSymbol is required when transferring from an isolated margin account
+	ToSymbol   string       `url:"toSymbol,omitempty"`   // ToSymbol is required when transferring to an isolated margin account
+}
+
+type Transfer struct {
+	TranID int64 `json:"tranId"`
+}
+
+// TransferHistoryOpts represents the opts used for querying universal transfers
+// Remark: Only transfers of the last 6 months are available
+type TransferHistoryOpts struct {
+	Type       TransferType `url:"type"`
+	StartTime  uint64       `url:"startTime,omitempty"`
+	EndTime    uint64       `url:"endTime,omitempty"`
+	Current    int          `url:"current,omitempty"` // Current is the page to receive, starting from 1
+	Size       int          `url:"size,omitempty"`    // Size is the number of elements per page. Max 100
+	FromSymbol string       `url:"fromSymbol,omitempty"`
+	ToSymbol   string       `url:"toSymbol,omitempty"`
+}
+
+type TransferHistory struct {
+	Total int                    `json:"total"` // Total is the number of transfers matching the query across all pages
+	Rows  []*TransferHistoryElem `json:"rows"`
+}
+
+type TransferHistoryElem struct {
+	TranID    int64          `json:"tranId"`
+	Type      TransferType   `json:"type"`
+	Asset     string         `json:"asset"`
+	Amount    string         `json:"amount"`
+	Status    TransferStatus `json:"status"`
+	Timestamp uint64         `json:"timestamp"`
+}
+
+// DustTransferOpts represents the opts used for converting small balances to BNB
+type DustTransferOpts struct {
+	Assets []string `url:"asset"` // Assets are the assets to convert, each of them is sent as a separate asset parameter
+}
+
+type DustTransfer struct {
+	TotalServiceCharge string                `json:"totalServiceCharge"`
+	TotalTransfered    string                `json:"totalTransfered"` // TotalTransfered is the BNB amount received
+	TransferResult     []*DustTransferResult `json:"transferResult"`
+}
+
+type DustTransferResult struct {
+	TranID              int64  `json:"tranId"`
+	FromAsset           string `json:"fromAsset"`
+	Amount              string `json:"amount"`
+	TransferedAmount    string `json:"transferedAmount"`
+	ServiceChargeAmount string `json:"serviceChargeAmount"`
+	OperateTime         uint64 `json:"operateTime"`
+}
+
+// DustLogOpts represents the opts used for querying dust conversions
+// Remark: Only conversions of the last 100 days are available
+type DustLogOpts struct {
+	StartTime uint64 `url:"startTime,omitempty"`
+	EndTime   uint64 `url:"endTime,omitempty"`
+}
+
+type DustLog struct {
+	Total     int         `json:"total"`
+	Dribblets []*Dribblet `json:"userAssetDribblets"` // Dribblets are the conversions, each of them may convert several assets
+}
+
+type Dribblet struct {
+	TransID                  int64             `json:"transId"`
+	OperateTime              uint64            `json:"operateTime"`
+	TotalTransferedAmount    string            `json:"totalTransferedAmount"`
+	TotalServiceChargeAmount string            `json:"totalServiceChargeAmount"`
+	Details                  []*DribbletDetail `json:"userAssetDribbletDetails"`
+}
+
+type DribbletDetail struct {
+	TransID             int64  `json:"transId"`
+	FromAsset           string `json:"fromAsset"`
+	Amount              string `json:"amount"`
+	TransferedAmount    string `json:"transferedAmount"`
+	ServiceChargeAmount string `json:"serviceChargeAmount"`
+	OperateTime         uint64 `json:"operateTime"`
+}
+
+// AssetDetail represents the deposit and withdrawal configuration of an asset
+type AssetDetail struct {
+	MinWithdrawAmount string `json:"minWithdrawAmount"`
+	DepositStatus     bool   `json:"depositStatus"`
+	WithdrawFee       string `json:"withdrawFee"`
+	WithdrawStatus    bool   `json:"withdrawStatus"`
+	DepositTip        string `json:"depositTip"` // DepositTip is the reason deposits are disabled, if any
+}
+
+type AssetDetailOpts struct {
+	Asset string `url:"asset,omitempty"` // Asset, if not set, details of all assets are received
+}
+
+type TradeFeeOpts struct {
+	Symbol string `url:"symbol,omitempty"` // Symbol, if not set, fees of all symbols are received
+}
+
+type TradeFee struct {
+	Symbol          string `json:"symbol"`
+	MakerCommission string `json:"makerCommission"`
+	TakerCommission string `json:"takerCommission"`
+}
+
+// AssetDividendOpts represents the opts used for querying asset dividends
+// Remark: The distance between StartTime and EndTime must be less than 180 days
+type AssetDividendOpts struct {
+	Asset     string `url:"asset,omitempty"`
+	StartTime uint64 `url:"startTime,omitempty"`
+	EndTime   uint64 `url:"endTime,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 20, max 500
+}
+
+type AssetDividendHistory struct {
+	Total int              `json:"total"`
+	Rows  []*AssetDividend `json:"rows"`
+}
+
+type AssetDividend struct {
+	ID      int64  `json:"id"`
+	TranID  int64  `json:"tranId"`
+	Asset   string `json:"asset"`
+	Amount  string `json:"amount"`
+	DivTime uint64 `json:"divTime"`
+	EnInfo  string `json:"enInfo"` // EnInfo is the dividend description, e.g. "BNB distribution"
+}
@@ -1,5 +1,7 @@
 package binance
 
+import "encoding/json"
+
 type UpdateType string
 
 const (
@@ -7,8 +9,53 @@ const (
 	UpdateTypeKline  UpdateType = "kline"
 	UpdateTypeTrades UpdateType = "aggTrade"
 
-	UpdateTypeOutboundAccountInfo UpdateType = "outboundAccountInfo"
-	UpdateTypeExecutionReport     UpdateType = "executionReport"
+	UpdateTypeRawTrade   UpdateType = "trade"
+	UpdateTypeTicker     UpdateType = "24hrTicker"
+	UpdateTypeMiniTicker UpdateType = "24hrMiniTicker"
+	UpdateTypeAvgPrice   UpdateType = "avgPrice"
+
+	UpdateTypeOutboundAccountInfo     UpdateType = "outboundAccountInfo"
+	UpdateTypeOutboundAccountPosition UpdateType = "outboundAccountPosition"
+	UpdateTypeBalanceUpdate           UpdateType = "balanceUpdate"
+	UpdateTypeExecutionReport         UpdateType = "executionReport"
+	UpdateTypeListStatus              UpdateType = "listStatus"
+	UpdateTypeListenKeyExpired        UpdateType = "listenKeyExpired"
+
+	UpdateTypeMarkPrice       UpdateType = "markPriceUpdate"
+	UpdateTypeForceOrder      UpdateType = "forceOrder"
+	UpdateTypeContinuousKline UpdateType = "continuous_kline"
+	UpdateTypeIndexPriceKline UpdateType = "indexPriceKline"
+	UpdateTypeCompositeIndex  UpdateType = "compositeIndex"
+
+	UpdateTypeOrderTradeUpdate     UpdateType = "ORDER_TRADE_UPDATE"
+	UpdateTypeFuturesAccountUpdate UpdateType = "ACCOUNT_UPDATE"
+)
+
+// UpdateSpeed represents the interval in which depth streams push their updates
+type UpdateSpeed string
+
+const (
+	UpdateSpeed1000ms UpdateSpeed = ""      // UpdateSpeed1000ms is the default update speed
+	UpdateSpeed100ms  UpdateSpeed = "100ms" // UpdateSpeed100ms pushes updates every 100ms
+	UpdateSpeed1s     UpdateSpeed = "1s"    // UpdateSpeed1s pushes mark price updates every second rather than every 3 seconds
+)
+
+// DepthLevels represents the number of order book levels pushed by partial depth streams
+type DepthLevels int
+
+const (
+	DepthLevels5  DepthLevels = 5
+	DepthLevels10 DepthLevels = 10
+	DepthLevels20 DepthLevels = 20
+)
+
+// RollingWindow represents the window size of rolling window ticker statistics
+type RollingWindow string
+
+const (
+	RollingWindow1h RollingWindow = "1h"
+	RollingWindow4h RollingWindow = "4h"
+	RollingWindow1d RollingWindow = "1d"
 )
 
 // DepthUpdate represents the incoming messages for depth websocket updates
@@ -23,28 +70,31 @@ type DepthUpdate struct {
 
 // KlinesUpdate represents the incoming messages for klines websocket updates
 type KlinesUpdate struct {
-	EventType UpdateType `json:"e"` // EventType represents the update type
-	Time      uint64     `json:"E"` // Time represents the event time
-	Symbol    string     `json:"s"` // Symbol represents the symbol related to the update
-	Kline     struct {
-		StartTime    uint64        `json:"t"` // StartTime is the start time of this bar
-		EndTime      uint64        `json:"T"` // EndTime is the end time of this bar
-		Symbol       string        `json:"s"` // Symbol represents the symbol related to this kline
-		Interval     KlineInterval `json:"i"` // Interval is the kline interval
-		FirstTradeID int           `json:"f"` // FirstTradeID is the first trade ID
-		LastTradeID  int           `json:"L"` // LastTradeID is the first trade ID
-
-		OpenPrice            string `json:"o"` // OpenPrice represents the open price for this bar
-		ClosePrice           string `json:"c"` // ClosePrice represents the close price for this bar
-		High                 string `json:"h"` // High represents the highest price for this bar
-		Low                  string `json:"l"` // Low represents the lowest price for this bar
-		Volume               string `json:"v"` // Volume is the trades volume for this bar
-		Trades               int    `json:"n"` // Trades is the number of conducted trades
-		Final                bool   `json:"x"` // Final indicates whether this bar is final or yet may receive updates
-		VolumeQuote          string `json:"q"` // VolumeQuote indicates the quote volume for the symbol
-		VolumeActiveBuy      string `json:"V"` // VolumeActiveBuy represents the volume of active buy
-		VolumeQuoteActiveBuy string `json:"Q"` // VolumeQuoteActiveBuy represents the quote volume of active buy
-	} `json:"k"` // Kline is the kline update
+	EventType UpdateType  `json:"e"` // EventType represents the update type
+	Time      uint64      `json:"E"` // Time represents the event time
+	Symbol    string      `json:"s"` // Symbol represents the symbol related to the update
+	Kline     StreamKline `json:"k"` // Kline is the kline update
+}
+
+// StreamKline represents the kline bar carried by klines websocket updates
+type StreamKline struct {
+	StartTime    uint64        `json:"t"` // StartTime is the start time of this bar
+	EndTime      uint64        `json:"T"` // EndTime is the end time of this bar
+	Symbol       string        `json:"s"` // Symbol represents the symbol related to this kline
+	Interval     KlineInterval `json:"i"` // Interval is the kline interval
+	FirstTradeID int           `json:"f"` // FirstTradeID is the first trade ID
+	LastTradeID  int           `json:"L"` // LastTradeID is the first trade ID
+
+	OpenPrice            string `json:"o"` // OpenPrice represents the open price for this bar
+	ClosePrice           string `json:"c"` // ClosePrice represents the close price for this bar
+	High                 string `json:"h"` // High represents the highest price for this bar
+	Low                  string `json:"l"` // Low represents the lowest price for this bar
+	Volume               string `json:"v"` // Volume is the trades volume for this bar
+	Trades               int    `json:"n"` // Trades is the number of conducted trades
+	Final                bool   `json:"x"` // Final indicates whether this bar is final or yet may receive updates
+	VolumeQuote          string `json:"q"` // VolumeQuote indicates the quote volume for the symbol
+	VolumeActiveBuy      string `json:"V"` // VolumeActiveBuy represents the volume of active buy
+	VolumeQuoteActiveBuy string `json:"Q"` // VolumeQuoteActiveBuy represents the quote volume of active buy
 }
 
 // TradesUpdate represents the incoming messages for aggregated trades websocket updates
@@ -59,9 +109,153 @@ type TradesUpdate struct {
 	LastBreakDownTradeID  int        `json:"l"` // LastBreakDownTradeID is the last breakdown trade ID
 	TradeTime             uint64     `json:"T"` // Time is the trade time
 	Maker                 bool       `json:"m"` // Maker indicates whether buyer is a maker
+	BestMatch             bool       `json:"M"` // BestMatch indicates if the trade was at the best price match
+}
+
+// PartialDepthUpdate represents the incoming messages for partial depth websocket updates
+// Remark: Each message is a snapshot of the top order book levels rather than a diff
+type PartialDepthUpdate struct {
+	LastUpdateID int         `json:"lastUpdateId"` // LastUpdateID is the last update ID included in the snapshot
+	Bids         []DepthElem `json:"bids"`         // Bids is a list of top bids for symbol
+	Asks         []DepthElem `json:"asks"`         // Asks is a list of top asks for symbol
+}
+
+// RawTradeUpdate represents the incoming messages for raw trades websocket updates
+type RawTradeUpdate struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      uint64     `json:"E"` // Time represents the event time
+	Symbol    string     `json:"s"` // Symbol represents the symbol related to the update
+	TradeID   int        `json:"t"` // TradeID is the trade ID
+	Price     string     `json:"p"` // Price is the trade price
+	Quantity  string     `json:"q"` // Quantity is the trade quantity
+	TradeTime uint64     `json:"T"` // TradeTime is the trade time
+	Maker     bool       `json:"m"` // Maker indicates whether buyer is a maker
+	BestMatch bool       `json:"M"` // BestMatch indicates if the trade was at the best price match
 }
 
+// TickerUpdate represents the incoming messages for 24 hour ticker statistics websocket updates
+type TickerUpdate struct {
+	EventType          UpdateType `json:"e"` // EventType represents the update type
+	Time               uint64     `json:"E"` // Time represents the event time
+	Symbol             string     `json:"s"` // Symbol represents the symbol related to the update
+	PriceChange        string     `json:"p"` // PriceChange is the price change over the window
+	PriceChangePercent string     `json:"P"` // PriceChangePercent is the price change percentage over the window
+	WeightedAvgPrice   string     `json:"w"` // WeightedAvgPrice is the weighted average price over the window
+	PrevClosePrice     string     `json:"x"` // PrevClosePrice is the price of the last trade before the window
+	LastPrice          string     `json:"c"` // LastPrice is the last trade price
+	LastQty            string     `json:"Q"` // LastQty is the last trade quantity
+	BidPrice           string     `json:"b"` // BidPrice is the best bid price
+	BidQty             string     `json:"B"` // BidQty is the best bid quantity
+	AskPrice           string     `json:"a"` // AskPrice is the best ask price
+	AskQty             string     `json:"A"` // AskQty is the best ask quantity
+	OpenPrice          string     `json:"o"` // OpenPrice is the open price of the window
+	High               string     `json:"h"` // High is the highest price over the window
+	Low                string     `json:"l"` // Low is the lowest price over the window
+	Volume             string     `json:"v"` // Volume is the total traded base asset volume over the window
+	VolumeQuote        string     `json:"q"` // VolumeQuote is the total traded quote asset volume over the window
+	OpenTime           uint64     `json:"O"` // OpenTime is the window open time
+	CloseTime          uint64     `json:"C"` // CloseTime is the window close time
+	FirstTradeID       int        `json:"F"` // FirstTradeID is the first trade ID within the window
+	LastTradeID        int        `json:"L"` // LastTradeID is the last trade ID within the window
+	Trades             int        `json:"n"` // Trades is the number of trades within the window
+}
+
+// MiniTickerUpdate represents the incoming messages for 24 hour mini ticker statistics websocket updates
+type MiniTickerUpdate struct {
+	EventType   UpdateType `json:"e"` // EventType represents the update type
+	Time        uint64     `json:"E"` // Time represents the event time
+	Symbol      string     `json:"s"` // Symbol represents the symbol related to the update
+	ClosePrice  string     `json:"c"` // ClosePrice is the last trade price
+	OpenPrice   string     `json:"o"` // OpenPrice is the open price of the window
+	High        string     `json:"h"` // High is the highest price over the window
+	Low         string     `json:"l"` // Low is the lowest price over the window
+	Volume      string     `json:"v"` // Volume is the total traded base asset volume over the window
+	VolumeQuote string     `json:"q"` // VolumeQuote is the total traded quote asset volume over the window
+}
+
+// BookTickerUpdate represents the incoming messages for best bid and ask websocket updates
+type BookTickerUpdate struct {
+	UpdateID int    `json:"u"` // UpdateID is the order book update ID
+	Symbol   string `json:"s"` // Symbol represents the symbol related to the update
+	BidPrice string `json:"b"` // BidPrice is the best bid price
+	BidQty   string `json:"B"` // BidQty is the best bid quantity
+	AskPrice string `json:"a"` // AskPrice is the best ask price
+	AskQty   string `json:"A"` // AskQty is the best ask quantity
+}
+
+// RollingTickerUpdate represents the incoming messages for rolling window ticker statistics websocket updates
+// Remark: The event type carries the window size, e.g. "1hTicker"
+type RollingTickerUpdate struct {
+	EventType          UpdateType `json:"e"` // EventType represents the update type
+	Time               uint64     `json:"E"` // Time represents the event time
+	Symbol             string     `json:"s"` // Symbol represents the symbol related to the update
+	PriceChange        string     `json:"p"` // PriceChange is the price change over the window
+	PriceChangePercent string     `json:"P"` // PriceChangePercent is the price change percentage over the window
+	OpenPrice          string     `json:"o"` // OpenPrice is the open price of the window
+	High               string     `json:"h"` // High is the highest price over the window
+	Low                string     `json:"l"` // Low is the lowest price over the window
+	LastPrice          string     `json:"c"` // LastPrice is the last trade price
+	WeightedAvgPrice   string     `json:"w"` // WeightedAvgPrice is the weighted average price over the window
+	Volume             string     `json:"v"` // Volume is the total traded base asset volume over the window
+	VolumeQuote        string     `json:"q"` // VolumeQuote is the total traded quote asset volume over the window
+	OpenTime           uint64     `json:"O"` // OpenTime is the window open time
+	CloseTime          uint64     `json:"C"` // CloseTime is the window close time
+	FirstTradeID       int        `json:"F"` // FirstTradeID is the first trade ID within the window
+	LastTradeID        int        `json:"L"` // LastTradeID is the last trade ID within the window
+	Trades             int        `json:"n"` // Trades is the number of trades within the window
+}
+
+// AvgPriceUpdate represents the incoming messages for average price websocket updates
+type AvgPriceUpdate struct {
+	EventType     UpdateType `json:"e"` // EventType represents the update type
+	Time          uint64     `json:"E"` // Time represents the event time
+	Symbol        string     `json:"s"` // Symbol represents the symbol related to the update
+	Interval      string     `json:"i"` // Interval is the average price interval, e.g. "5m"
+	Price         string     `json:"w"` // Price is the average price
+	LastTradeTime uint64     `json:"T"` // LastTradeTime is the time of the last trade included in the average
+}
+
+// CombinedUpdate represents the incoming messages for combined streams websocket updates
+// Remark: Only the update matching the type of Stream is set, the others are nil
+type CombinedUpdate struct {
+	Stream         string               // Stream is the name of the stream the update was received on
+	Depth          *DepthUpdate         // Depth is set for depth streams
+	PartialDepth   *PartialDepthUpdate  // PartialDepth is set for partial depth streams
+	Klines         *KlinesUpdate        // Klines is set for klines streams
+	Trades         *TradesUpdate        // Trades is set for aggregated trades streams
+	RawTrade       *RawTradeUpdate      // RawTrade is set for raw trades streams
+	Ticker         *TickerUpdate        // Ticker is set for ticker streams
+	AllTickers     []*TickerUpdate      // AllTickers is set for the all market tickers stream
+	MiniTicker     *MiniTickerUpdate    // MiniTicker is set for mini ticker streams
+	AllMiniTickers []*MiniTickerUpdate  // AllMiniTickers is set for the all market mini tickers stream
+	BookTicker     *BookTickerUpdate    // BookTicker is set for book ticker streams
+	RollingTicker  *RollingTickerUpdate // RollingTicker is set for rolling window ticker streams
+	AvgPrice       *AvgPriceUpdate      // AvgPrice is set for average price streams
+
+	MarkPrice       *MarkPriceUpdate       // MarkPrice is set for futures mark price streams
+	AllMarkPrices   []*MarkPriceUpdate     // AllMarkPrices is set for the futures all market mark prices stream
+	Liquidation     *LiquidationUpdate     // Liquidation is set for futures liquidation order streams, including the all market stream
+	ContinuousKline *ContinuousKlineUpdate // ContinuousKline is set for futures continuous contract klines streams
+	IndexPriceKline *IndexPriceKlineUpdate // IndexPriceKline is set for futures index price klines streams
+	CompositeIndex  *CompositeIndexUpdate  // CompositeIndex is set for futures composite index streams
+}
+
+// UserDataEvent is a user data update, which is one of *AccountUpdate, *AccountPositionUpdate, *BalanceUpdate,
+// *OrderUpdate, *ListStatusUpdate, *ListenKeyExpiredUpdate or *UnknownUserUpdate
+type UserDataEvent interface {
+	userDataEvent()
+}
+
+func (*AccountUpdate) userDataEvent()          {}
+func (*AccountPositionUpdate) userDataEvent()  {}
+func (*BalanceUpdate) userDataEvent()          {}
+func (*OrderUpdate) userDataEvent()            {}
+func (*ListStatusUpdate) userDataEvent()       {}
+func (*ListenKeyExpiredUpdate) userDataEvent() {}
+func (*UnknownUserUpdate) userDataEvent()      {}
+
 // AccountUpdate represents the incoming messages for account info websocket updates
+// Remark: This is the legacy account update, superseded by AccountPositionUpdate
 type AccountUpdate struct {
 	EventType        UpdateType `json:"e"` // EventType represents the update type
 	Time             uint64     `json:"E"` // Time represents the event time
@@ -79,28 +273,112 @@ type AccountUpdate struct {
 	} `json:"B"`
 }
 
+// AccountPositionUpdate represents the incoming messages for account balances changes
+// Remark: Only the balances of assets which changed are included
+type AccountPositionUpdate struct {
+	EventType      UpdateType         `json:"e"` // EventType represents the update type
+	Time           uint64             `json:"E"` // Time represents the event time
+	LastUpdateTime uint64             `json:"u"` // LastUpdateTime is the time of the last account update
+	Balances       []*BalancePosition `json:"B"` // Balances are the balances of the changed assets
+}
+
+// BalancePosition represents the balance of a specific asset within account position updates
+type BalancePosition struct {
+	Asset  string `json:"a"` // Asset is the asset name
+	Free   string `json:"f"` // Free is the free amount
+	Locked string `json:"l"` // Locked is the locked amount
+}
+
+// BalanceUpdate represents the incoming messages for balance changes by deposits, withdrawals and transfers
+type BalanceUpdate struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      uint64     `json:"E"` // Time represents the event time
+	Asset     string     `json:"a"` // Asset is the asset which balance changed
+	Delta     string     `json:"d"` // Delta is the balance change
+	ClearTime uint64     `json:"T"` // ClearTime is the time the change was cleared
+}
+
 // OrderUpdate represents the incoming messages for account orders websocket updates
 type OrderUpdate struct {
-	EventType        UpdateType   `json:"e"` // EventType represents the update type
-	Time             uint64       `json:"E"` // Time represents the event time
-	Symbol           string       `json:"s"` // Symbol represents the symbol related to the update
-	NewClientOrderID string       `json:"c"` // NewClientOrderID is the new client order ID
-	Side             OrderSide    `json:"S"` // Side is the order side
-	OrderType        OrderType    `json:"o"` // OrderType represents the order type
-	TimeInForce      TimeInForce  `json:"f"` // TimeInForce represents the order TIF type
-	OrigQty          string       `json:"q"` // OrigQty represents the order original quantity
-	Price            string       `json:"p"` // Price is the order price
-	ExecutionType    OrderStatus  `json:"x"` // ExecutionType represents the execution type for the order
-	Status           OrderStatus  `json:"X"` // Status represents the order status for the order
-	Error            OrderFailure `json:"r"` // Error represents an order rejection reason
-	OrderID          int          `json:"i"` // OrderID represents the order ID
-	OrderTime        uint64       `json:"T"` // OrderTime represents the order time
-	FilledQty        string       `json:"l"` // FilledQty represents the quantity of the last filled trade
-	FilledPrice      string       `json:"L"` // FilledPrice is the price of last filled trade
-	TotalFilledQty   string       `json:"z"` // TotalFilledQty is the accumulated quantity of filled trades on this order
-	Commission       string       `json:"n"` // Commission is the commission for the trade
-	CommissionAsset  string       `json:"N"` // CommissionAsset is the asset on which commission is taken
-	TradeTime        uint64       `json:"T"` // TradeTime is the trade time
-	TradeID          int          `json:"t"` // TradeID represents the trade ID
-	Maker            bool         `json:"m"` // Maker represents whether buyer is maker or not
+	EventType               UpdateType              `json:"e"` // EventType represents the update type
+	Time                    uint64                  `json:"E"` // Time represents the event time
+	Symbol                  string                  `json:"s"` // Symbol represents the symbol related to the update
+	NewClientOrderID        string                  `json:"c"` // NewClientOrderID is the new client order ID
+	Side                    OrderSide               `json:"S"` // Side is the order side
+	OrderType               OrderType               `json:"o"` // OrderType represents the order type
+	TimeInForce             TimeInForce             `json:"f"` // TimeInForce represents the order TIF type
+	OrigQty                 string                  `json:"q"` // OrigQty represents the order original quantity
+	Price                   string                  `json:"p"` // Price is the order price
+	StopPrice               string                  `json:"P"` // StopPrice is the order stop price
+	IcebergQty              string                  `json:"F"` // IcebergQty is the order iceberg quantity
+	OrderListID             int                     `json:"g"` // OrderListID is the ID of the order list the order belongs to, or -1
+	OrigClientOrderID       string                  `json:"C"` // OrigClientOrderID is the client order ID of the order being canceled
+	ExecutionType           OrderStatus             `json:"x"` // ExecutionType represents the execution type for the order
+	Status                  OrderStatus             `json:"X"` // Status represents the order status for the order
+	Error                   OrderFailure            `json:"r"` // Error represents an order rejection reason
+	OrderID                 int                     `json:"i"` // OrderID represents the order ID
+	FilledQty               string                  `json:"l"` // FilledQty represents the quantity of the last filled trade
+	TotalFilledQty          string                  `json:"z"` // TotalFilledQty is the accumulated quantity of filled trades on this order
+	FilledPrice             string                  `json:"L"` // FilledPrice is the price of last filled trade
+	Commission              string                  `json:"n"` // Commission is the commission for the trade
+	CommissionAsset         string                  `json:"N"` // CommissionAsset is the asset on which commission is taken
+	TradeTime               uint64                  `json:"T"` // TradeTime is the transaction time
+	TradeID                 int                     `json:"t"` // TradeID represents the trade ID
+	PreventedMatchID        int                     `json:"v"` // PreventedMatchID is the ID of the prevented match, set on expiry by self trade prevention
+	Working                 bool                    `json:"w"` // Working indicates whether the order is on the book
+	Maker                   bool                    `json:"m"` // Maker represents whether buyer is maker or not
+	OrderTime               uint64                  `json:"O"` // OrderTime represents the order creation time
+	CumulativeQuoteQty      string                  `json:"Z"` // CumulativeQuoteQty is the accumulated quote quantity of filled trades on this order
+	FilledQuoteQty          string                  `json:"Y"` // FilledQuoteQty is the quote quantity of the last filled trade
+	QuoteOrderQty           string                  `json:"Q"` // QuoteOrderQty is the order quote quantity
+	WorkingTime             uint64                  `json:"W"` // WorkingTime is the time the order was put on the book
+	SelfTradePreventionMode SelfTradePreventionMode `json:"V"` // SelfTradePreventionMode is the order self trade prevention mode
+	PreventedQty            string                  `json:"A"` // PreventedQty is the quantity expired by self trade prevention
+	LastPreventedQty        string                  `json:"B"` // LastPreventedQty is the quantity expired by the last prevented match
+	TradeGroupID            int                     `json:"u"` // TradeGroupID is the trade group ID of the prevented match
+	CounterOrderID          int                     `json:"U"` // CounterOrderID is the ID of the counter order of the prevented match
+	TrailingDelta           int                     `json:"d"` // TrailingDelta is the order trailing delta
+	TrailingTime            uint64                  `json:"D"` // TrailingTime is the time the trailing order was activated
+	StrategyID              int                     `json:"j"` // StrategyID is the order strategy ID
+	StrategyType            int                     `json:"J"` // StrategyType is the order strategy type
+	MatchType               string                  `json:"b"` // MatchType is the match type of orders routed by SOR, e.g. ONE_PARTY_TRADE_REPORT
+	AllocationID            int                     `json:"a"` // AllocationID is the ID of the allocation of orders routed by SOR
+
+	// The following fields are ignored by the exchange, and are declared only to prevent them from being decoded into
+	// the fields sharing their key in a different case
+	IgnoreI json.RawMessage `json:"I"`
+	IgnoreM json.RawMessage `json:"M"`
+}
+
+// ListStatusUpdate represents the incoming messages for order lists websocket updates
+type ListStatusUpdate struct {
+	EventType         UpdateType      `json:"e"` // EventType represents the update type
+	Time              uint64          `json:"E"` // Time represents the event time
+	Symbol            string          `json:"s"` // Symbol represents the symbol related to the update
+	OrderListID       int             `json:"g"` // OrderListID is the order list ID
+	ContingencyType   ContingencyType `json:"c"` // ContingencyType is the order list type
+	ListStatusType    ListStatusType  `json:"l"` // ListStatusType is the order list status
+	ListOrderStatus   ListOrderStatus `json:"L"` // ListOrderStatus is the status of the order list orders
+	Error             string          `json:"r"` // Error represents an order list rejection reason
+	ListClientOrderID string          `json:"C"` // ListClientOrderID is the order list client order ID
+	TransactionTime   uint64          `json:"T"` // TransactionTime is the transaction time
+	Orders            []*struct {
+		Symbol        string `json:"s"` // Symbol is the order symbol
+		OrderID       int    `json:"i"` // OrderID is the order ID
+		ClientOrderID string `json:"c"` // ClientOrderID is the order client order ID
+	} `json:"O"` // Orders are the orders of the order list
+}
+
+// ListenKeyExpiredUpdate represents the incoming message sent once the datastream key expires
+type ListenKeyExpiredUpdate struct {
+	EventType UpdateType `json:"e"`         // EventType represents the update type
+	Time      uint64     `json:"E"`         // Time represents the event time
+	ListenKey string     `json:"listenKey"` // ListenKey is the expired datastream key
+}
+
+// UnknownUserUpdate represents an incoming user data message of an event type which is not supported
+type UnknownUserUpdate struct {
+	EventType UpdateType // EventType represents the update type
+	Time      uint64     // Time represents the event time
+	Data      []byte     // Data is the raw message
 }
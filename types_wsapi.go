@@ -0,0 +1,35 @@
+package binance
+
+import "crypto/ed25519"
+
+// WSAPIOpts configures websocket API connections
+type WSAPIOpts struct {
+	PrivateKey ed25519.PrivateKey        // PrivateKey, if set, is used to log the session on with the Ed25519 API key of the client
+	OnUserData func(event UserDataEvent) // OnUserData, if set, handles the user data events of a subscribed session
+	OnError    func(err error)           // OnError, if set, handles decode errors and the error that terminated the connection
+}
+
+// WSAPIError represents an error returned by the websocket API in response to a request
+type WSAPIError struct {
+	Status int    `json:"-"` // Status is the HTTP-like status of the response
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+// WSAPIRateLimit represents the usage of a rate limit, as reported along with websocket API responses
+type WSAPIRateLimit struct {
+	RateLimitType string `json:"rateLimitType"` // RateLimitType is one of REQUEST_WEIGHT, ORDERS or CONNECTIONS
+	Interval      string `json:"interval"`      // Interval is one of SECOND, MINUTE or DAY
+	IntervalNum   int    `json:"intervalNum"`
+	Limit         int    `json:"limit"`
+	Count         int    `json:"count"` // Count is the current usage within the interval
+}
+
+// WSAPISession represents the status of a websocket API session
+type WSAPISession struct {
+	APIKey           string `json:"apiKey"` // APIKey is the logged on API key, empty if not logged on
+	AuthorizedSince  uint64 `json:"authorizedSince"`
+	ConnectedSince   uint64 `json:"connectedSince"`
+	ReturnRateLimits bool   `json:"returnRateLimits"`
+	ServerTime       uint64 `json:"serverTime"`
+}
@@ -0,0 +1,292 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	defaultKeepAliveInterval = 30 * time.Minute
+	userStreamRetryMin       = time.Second
+	userStreamRetryMax       = time.Minute
+)
+
+// ErrListenKeyExpired is reported by a user stream when its datastream key expired and had to be recreated
+var ErrListenKeyExpired = errors.New("listen key expired")
+
+// UserStreamOpts are used to configure user streams
+type UserStreamOpts struct {
+	BufferSize        int           // BufferSize is the capacity of the updates channels. Default 64
+	KeepAliveInterval time.Duration // KeepAliveInterval is the interval in which the datastream key is kept alive. Default 30 minutes
+}
+
+// userStreamAPI are the calls a user stream makes to manage its datastream key and websocket
+type userStreamAPI interface {
+	DataStream() (string, error)
+	DataStreamKeepAlive(listenKey string) error
+	DataStreamClose(listenKey string) error
+	AccountInfoWS(listenKey string) (*AccountInfoWS, error)
+}
+
+// UserStream is a user data stream which manages the lifecycle of its datastream key
+// Remark: Updates are delivered until the context the stream was opened with is cancelled, after which all channels are closed
+type UserStream struct {
+	ctx    context.Context
+	api    userStreamAPI
+	opts   UserStreamOpts
+	logger *slog.Logger
+
+	accountUpdates    chan *AccountUpdate
+	positionUpdates   chan *AccountPositionUpdate
+	balanceUpdates    chan *BalanceUpdate
+	orderUpdates      chan *OrderUpdate
+	listStatusUpdates chan *ListStatusUpdate
+	errc              chan error
+
+	mu        sync.Mutex
+	listenKey string
+	ws        *AccountInfoWS
+	closed    bool
+
+	wg sync.WaitGroup
+}
+
+func newUserStream(ctx context.Context, api userStreamAPI, opts *UserStreamOpts, logger *slog.Logger) *UserStream {
+	u := &UserStream{ctx: ctx, api: api, logger: orDiscard(logger)}
+	if opts != nil {
+		u.opts = *opts
+	}
+	if u.opts.BufferSize <= 0 {
+		u.opts.BufferSize = defaultSubscribeBufferSize
+	}
+	if u.opts.KeepAliveInterval <= 0 {
+		u.opts.KeepAliveInterval = defaultKeepAliveInterval
+	}
+	u.accountUpdates = make(chan *AccountUpdate, u.opts.BufferSize)
+	u.positionUpdates = make(chan *AccountPositionUpdate, u.opts.BufferSize)
+	u.balanceUpdates = make(chan *BalanceUpdate, u.opts.BufferSize)
+	u.orderUpdates = make(chan *OrderUpdate, u.opts.BufferSize)
+	u.listStatusUpdates = make(chan *ListStatusUpdate, u.opts.BufferSize)
+	u.errc = make(chan error, u.opts.BufferSize)
+	return u
+}
+
+// AccountUpdates returns the channel of legacy account updates
+func (u *UserStream) AccountUpdates() <-chan *AccountUpdate {
+	return u.accountUpdates
+}
+
+// AccountPositionUpdates returns the channel of account balances updates
+func (u *UserStream) AccountPositionUpdates() <-chan *AccountPositionUpdate {
+	return u.positionUpdates
+}
+
+// BalanceUpdates returns the channel of balance updates by deposits, withdrawals and transfers
+func (u *UserStream) BalanceUpdates() <-chan *BalanceUpdate {
+	return u.balanceUpdates
+}
+
+// OrderUpdates returns the channel of order updates
+func (u *UserStream) OrderUpdates() <-chan *OrderUpdate {
+	return u.orderUpdates
+}
+
+// ListStatusUpdates returns the channel of order list updates
+func (u *UserStream) ListStatusUpdates() <-chan *ListStatusUpdate {
+	return u.listStatusUpdates
+}
+
+// Errors returns the channel of errors the stream recovered from, e.g. ErrListenKeyExpired or a dropped websocket.
+// Events of unknown types are reported as *UnexpectedEventError
+// Remark: Errors are dropped while the channel is full
+func (u *UserStream) Errors() <-chan error {
+	return u.errc
+}
+
+func (u *UserStream) start() {
+	u.wg.Add(2)
+	go u.keepAlive()
+	go u.watchContext()
+	go u.run()
+}
+
+// connect creates a new datastream key and opens its websocket
+func (u *UserStream) connect() error {
+	key, err := u.api.DataStream()
+	if err != nil {
+		return err
+	}
+	ws, err := u.api.AccountInfoWS(key)
+	if err != nil {
+		u.api.DataStreamClose(key)
+		return err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.closed {
+		ws.Close()
+		u.api.DataStreamClose(key)
+		return u.ctx.Err()
+	}
+	u.listenKey = key
+	u.ws = ws
+	return nil
+}
+
+// disconnect closes the current websocket and its datastream key
+func (u *UserStream) disconnect() {
+	u.mu.Lock()
+	key, ws := u.listenKey, u.ws
+	u.listenKey, u.ws = "", nil
+	u.mu.Unlock()
+	if ws != nil {
+		ws.Close()
+	}
+	if key != "" {
+		u.api.DataStreamClose(key)
+	}
+}
+
+// run reads updates and reconnects with a new datastream key whenever reading fails or the key expires
+func (u *UserStream) run() {
+	defer func() {
+		u.wg.Wait()
+		u.disconnect()
+		close(u.accountUpdates)
+		close(u.positionUpdates)
+		close(u.balanceUpdates)
+		close(u.orderUpdates)
+		close(u.listStatusUpdates)
+		close(u.errc)
+	}()
+	for {
+		err := u.read()
+		if u.ctx.Err() != nil {
+			return
+		}
+		if err == ErrListenKeyExpired {
+			u.logger.Info("binance user stream listen key expired, reconnecting")
+		} else {
+			u.logger.Warn("binance user stream failed, reconnecting", slog.Any("error", err))
+		}
+		u.report(err)
+		u.disconnect()
+		for retry := userStreamRetryMin; ; retry *= 2 {
+			err := u.connect()
+			if err == nil {
+				u.logger.Info("binance user stream reconnected")
+				break
+			}
+			if u.ctx.Err() != nil {
+				return
+			}
+			u.report(err)
+			if retry > userStreamRetryMax {
+				retry = userStreamRetryMax
+			}
+			u.logger.Warn("binance user stream reconnect failed, retrying", slog.Any("error", err), slog.Duration("retry", retry))
+			select {
+			case <-u.ctx.Done():
+				return
+			case <-time.After(retry):
+			}
+		}
+	}
+}
+
+// read delivers the updates of the current websocket until reading fails or the datastream key expires
+func (u *UserStream) read() error {
+	u.mu.Lock()
+	ws := u.ws
+	u.mu.Unlock()
+	for {
+		event, err := ws.ReadEvent()
+		if err != nil {
+			return err
+		}
+		switch update := event.(type) {
+		case *AccountUpdate:
+			select {
+			case u.accountUpdates <- update:
+			case <-u.ctx.Done():
+			}
+		case *AccountPositionUpdate:
+			select {
+			case u.positionUpdates <- update:
+			case <-u.ctx.Done():
+			}
+		case *BalanceUpdate:
+			select {
+			case u.balanceUpdates <- update:
+			case <-u.ctx.Done():
+			}
+		case *OrderUpdate:
+			select {
+			case u.orderUpdates <- update:
+			case <-u.ctx.Done():
+			}
+		case *ListStatusUpdate:
+			select {
+			case u.listStatusUpdates <- update:
+			case <-u.ctx.Done():
+			}
+		case *ListenKeyExpiredUpdate:
+			return ErrListenKeyExpired
+		default:
+			u.logger.Warn("binance user stream event unexpected", slog.String("type", fmt.Sprintf("%T", event)))
+			u.report(&UnexpectedEventError{Event: event})
+		}
+		if u.ctx.Err() != nil {
+			return u.ctx.Err()
+		}
+	}
+}
+
+// keepAlive keeps the current datastream key alive until the context is cancelled
+func (u *UserStream) keepAlive() {
+	defer u.wg.Done()
+	ticker := time.NewTicker(u.opts.KeepAliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-u.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		u.mu.Lock()
+		key := u.listenKey
+		u.mu.Unlock()
+		if key == "" {
+			continue
+		}
+		if err := u.api.DataStreamKeepAlive(key); err != nil {
+			u.logger.Warn("binance user stream listen key renewal failed", slog.Any("error", err))
+			u.report(err)
+			continue
+		}
+		u.logger.Debug("binance user stream listen key renewed")
+	}
+}
+
+// watchContext closes the current websocket once the context is cancelled, to release the pending read
+func (u *UserStream) watchContext() {
+	defer u.wg.Done()
+	<-u.ctx.Done()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.closed = true
+	if u.ws != nil {
+		u.ws.Close()
+	}
+}
+
+func (u *UserStream) report(err error) {
+	select {
+	case u.errc <- err:
+	default:
+	}
+}
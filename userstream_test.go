@@ -0,0 +1,98 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeUserStreamAPI manages datastream keys of a local user data server
+type fakeUserStreamAPI struct {
+	addr string
+
+	mu     sync.Mutex
+	keys   int
+	closed []string
+}
+
+func (f *fakeUserStreamAPI) DataStream() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.keys++
+	return fmt.Sprintf("key%d", f.keys), nil
+}
+
+func (f *fakeUserStreamAPI) DataStreamKeepAlive(listenKey string) error {
+	return nil
+}
+
+func (f *fakeUserStreamAPI) DataStreamClose(listenKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = append(f.closed, listenKey)
+	return nil
+}
+
+func (f *fakeUserStreamAPI) AccountInfoWS(listenKey string) (*AccountInfoWS, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(f.addr+listenKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountInfoWS{&wsWrapper{conn: conn}}, nil
+}
+
+// newUserDataServer starts a local server which expires the first datastream key after an order update,
+// and sends an account update on any following key
+func newUserDataServer(t *testing.T) string {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		messages := []string{`{"e":"outboundAccountInfo","E":2}`}
+		if r.URL.Path == "/key1" {
+			messages = []string{`{"e":"executionReport","E":1,"s":"ETHBTC"}`, `{"e":"listenKeyExpired","E":1}`}
+		}
+		for _, msg := range messages {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/"
+}
+
+func TestUserStream(t *testing.T) {
+	api := &fakeUserStreamAPI{addr: newUserDataServer(t)}
+	ctx, cancel := context.WithCancel(context.Background())
+	u := newUserStream(ctx, api, nil, nil)
+	require.NoError(t, u.connect())
+	u.start()
+
+	require.Equal(t, "ETHBTC", (<-u.OrderUpdates()).Symbol)
+	require.Equal(t, ErrListenKeyExpired, <-u.Errors())
+	require.Equal(t, uint64(2), (<-u.AccountUpdates()).Time)
+
+	cancel()
+	for range u.AccountUpdates() {
+	}
+	for range u.OrderUpdates() {
+	}
+	for range u.Errors() {
+	}
+	require.Equal(t, []string{"key1", "key2"}, api.closed)
+}
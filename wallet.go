@@ -0,0 +1,164 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Wallet endpoints
+
+// CoinsInfo get the configuration of all coins, including their networks, fees and limits, and the account balances of them
+func (b *BinanceClient) CoinsInfo() ([]*CoinInfo, error) {
+	resp := []*CoinInfo{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/capital/config/getall", nil, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DepositAddress get the deposit address of a coin
+func (b *BinanceClient) DepositAddress(opts *DepositAddressOpts) (*DepositAddress, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Coin == "" {
+		return nil, fmt.Errorf("coin is missing")
+	}
+	resp := &DepositAddress{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/capital/deposit/address", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DepositHistory get the account deposits
+func (b *BinanceClient) DepositHistory(opts *DepositHistoryOpts) ([]*Deposit, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*Deposit{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/capital/deposit/hisrec", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WithdrawHistory get the account withdrawals
+func (b *BinanceClient) WithdrawHistory(opts *WithdrawHistoryOpts) ([]*Withdrawal, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*Withdrawal{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/capital/withdraw/history", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Withdraw submits a withdrawal
+func (b *BinanceClient) Withdraw(opts *WithdrawOpts) (*Withdraw, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Coin == "" || opts.Address == "" || opts.Amount == "" {
+		return nil, fmt.Errorf("coin, address or amount are missing")
+	}
+	resp := &Withdraw{}
+	if err := b.client.doJSON(http.MethodPost, "sapi/v1/capital/withdraw/apply", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Transfer transfers funds between the account wallets, e.g. spot to futures
+func (b *BinanceClient) Transfer(opts *TransferOpts) (*Transfer, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Type == "" || opts.Asset == "" || opts.Amount == "" {
+		return nil, fmt.Errorf("type, asset or amount are missing")
+	}
+	resp := &Transfer{}
+	if err := b.client.doJSON(http.MethodPost, "sapi/v1/asset/transfer", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TransferHistory get the account universal transfers of a specific type
+func (b *BinanceClient) TransferHistory(opts *TransferHistoryOpts) (*TransferHistory, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.Type == "" {
+		return nil, fmt.Errorf("type is missing")
+	}
+	resp := &TransferHistory{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/asset/transfer", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DustTransfer converts small balances of the given assets to BNB
+func (b *BinanceClient) DustTransfer(opts *DustTransferOpts) (*DustTransfer, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if len(opts.Assets) == 0 {
+		return nil, fmt.Errorf("assets are missing")
+	}
+	resp := &DustTransfer{}
+	if err := b.client.doJSON(http.MethodPost, "sapi/v1/asset/dust", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DustLog get the account dust conversions
+func (b *BinanceClient) DustLog(opts *DustLogOpts) (*DustLog, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &DustLog{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/asset/dribblet", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AssetDetail get the deposit and withdrawal configuration of assets, mapped by asset
+func (b *BinanceClient) AssetDetail(opts *AssetDetailOpts) (map[string]*AssetDetail, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := map[string]*AssetDetail{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/asset/assetDetail", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TradeFee get the account trade fees of symbols
+func (b *BinanceClient) TradeFee(opts *TradeFeeOpts) ([]*TradeFee, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := []*TradeFee{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/asset/tradeFee", opts, true, false, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AssetDividendHistory get the account asset dividends, e.g. airdrops and staking rewards
+func (b *BinanceClient) AssetDividendHistory(opts *AssetDividendOpts) (*AssetDividendHistory, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	resp := &AssetDividendHistory{}
+	if err := b.client.doJSON(http.MethodGet, "sapi/v1/asset/assetDividend", opts, true, false, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package binance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"testing"
+)
+
+func TestDustTransfer(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/sapi/v1/asset/dust", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, []string{"ADA", "TRX"}, r.PostForm["asset"])
+		w.Write([]byte(`{"totalServiceCharge":"0.02","totalTransfered":"1.05","transferResult":[{"tranId":13,"fromAsset":"ADA","amount":"0.5"}]}`))
+	})
+	res, err := client.DustTransfer(&DustTransferOpts{Assets: []string{"ADA", "TRX"}})
+	require.NoError(t, err)
+	require.Equal(t, "1.05", res.TotalTransfered)
+	require.Equal(t, int64(13), res.TransferResult[0].TranID)
+}
+
+func TestWithdrawHistory(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sapi/v1/capital/withdraw/history", r.URL.Path)
+		require.Equal(t, "6", r.URL.Query().Get("status"))
+		w.Write([]byte(`[{"id":"b6ae22b3","coin":"USDT","network":"TRX","status":6,"addressTag":"101","applyTime":"2019-10-12 11:12:02"}]`))
+	})
+	status := WithdrawStatusCompleted
+	res, err := client.WithdrawHistory(&WithdrawHistoryOpts{Status: &status})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, WithdrawStatusCompleted, res[0].Status)
+	require.Equal(t, "101", res[0].AddressTag)
+}
+
+func TestWithdraw(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"id":"7213fea8e94b4a5593d507237e5a555b"}`))
+	})
+	walletType := WalletTypeFunding
+	res, err := client.Withdraw(&WithdrawOpts{
+		Coin:       "USDT",
+		Network:    "TRX",
+		Address:    "TXYZ",
+		AddressTag: "101",
+		Amount:     "10.5",
+		WalletType: &walletType,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "7213fea8e94b4a5593d507237e5a555b", res.ID)
+
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "/sapi/v1/capital/withdraw/apply", req.URL.Path)
+	require.Equal(t, "key", req.Header.Get("X-MBX-APIKEY"))
+	i := strings.LastIndex(string(body), "&signature=")
+	require.True(t, i > 0)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body[:i])
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), string(body[i+len("&signature="):]))
+	form, err := neturl.ParseQuery(string(body))
+	require.NoError(t, err)
+	require.Equal(t, "USDT", form.Get("coin"))
+	require.Equal(t, "TRX", form.Get("network"))
+	require.Equal(t, "TXYZ", form.Get("address"))
+	require.Equal(t, "101", form.Get("addressTag"))
+	require.Equal(t, "10.5", form.Get("amount"))
+	require.Equal(t, "1", form.Get("walletType"))
+	require.NotEmpty(t, form.Get("timestamp"))
+	require.NotContains(t, form, "transactionFeeFlag")
+
+	// Withdrawals lacking an address are not sent
+	req = nil
+	_, err = client.Withdraw(&WithdrawOpts{Coin: "USDT", Amount: "10.5"})
+	require.Error(t, err)
+	require.Nil(t, req)
+}
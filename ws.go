@@ -1,26 +1,364 @@
 package binance
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/gorilla/websocket"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+const (
+	// wsWriteWait is the time allowed to write a control frame
+	wsWriteWait = 10 * time.Second
+	// wsReadBufferMax is the capacity above which the read buffer of a websocket is released rather than reused
+	wsReadBufferMax = 1 << 20
+	// wsWatchIntervalMin is the minimal interval at which streams are checked for staleness
+	wsWatchIntervalMin = time.Millisecond
+)
+
+// DepthStream returns the stream name of depth updates for the given symbol
+func DepthStream(symbol string) string {
+	return strings.ToLower(symbol) + "@depth"
+}
+
+// KlinesStream returns the stream name of klines updates for the given symbol with the given interval
+func KlinesStream(symbol string, interval KlineInterval) string {
+	return fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
+}
+
+// TradesStream returns the stream name of aggregated trades updates for the given symbol
+func TradesStream(symbol string) string {
+	return strings.ToLower(symbol) + "@aggTrade"
+}
+
+// DepthSpeedStream returns the stream name of depth updates for the given symbol, pushed at the given update speed
+func DepthSpeedStream(symbol string, speed UpdateSpeed) string {
+	return withUpdateSpeed(DepthStream(symbol), speed)
+}
+
+// PartialDepthStream returns the stream name of the given number of top order book levels for the given symbol,
+// pushed at the given update speed
+func PartialDepthStream(symbol string, levels DepthLevels, speed UpdateSpeed) string {
+	return withUpdateSpeed(fmt.Sprintf("%s@depth%d", strings.ToLower(symbol), levels), speed)
+}
+
+// RawTradesStream returns the stream name of raw trades updates for the given symbol
+func RawTradesStream(symbol string) string {
+	return strings.ToLower(symbol) + "@trade"
+}
+
+// TickerStream returns the stream name of 24 hour ticker statistics updates for the given symbol
+func TickerStream(symbol string) string {
+	return strings.ToLower(symbol) + "@ticker"
+}
+
+// AllTickersStream returns the stream name of 24 hour ticker statistics updates for all symbols
+func AllTickersStream() string {
+	return "!ticker@arr"
+}
+
+// MiniTickerStream returns the stream name of 24 hour mini ticker statistics updates for the given symbol
+func MiniTickerStream(symbol string) string {
+	return strings.ToLower(symbol) + "@miniTicker"
+}
+
+// AllMiniTickersStream returns the stream name of 24 hour mini ticker statistics updates for all symbols
+func AllMiniTickersStream() string {
+	return "!miniTicker@arr"
+}
+
+// BookTickerStream returns the stream name of best bid and ask updates for the given symbol
+func BookTickerStream(symbol string) string {
+	return strings.ToLower(symbol) + "@bookTicker"
+}
+
+// RollingTickerStream returns the stream name of ticker statistics updates over the given rolling window for the given symbol
+func RollingTickerStream(symbol string, window RollingWindow) string {
+	return fmt.Sprintf("%s@ticker_%s", strings.ToLower(symbol), window)
+}
+
+// AvgPriceStream returns the stream name of average price updates for the given symbol
+func AvgPriceStream(symbol string) string {
+	return strings.ToLower(symbol) + "@avgPrice"
+}
+
+func withUpdateSpeed(stream string, speed UpdateSpeed) string {
+	if speed == UpdateSpeed1000ms {
+		return stream
+	}
+	return fmt.Sprintf("%s@%s", stream, speed)
+}
+
+// WSOpts configures the keepalive and the staleness detection of the websockets opened by the client
+// Remark: Staleness is detected by a watchdog which measures the time since the last message was read, hence it
+// requires the websocket to be read continuously. Account info websockets are not watched, as they are silent as long
+// as there is no account activity. A combined websocket is watched as a whole, under the name of its streams joined
+// by "/", hence StaleTimeouts cannot target a single one of its streams. Stream connections only apply the ping
+// interval and the read timeout: they are neither watched nor re-dialed, their Done channel signals their failure
+type WSOpts struct {
+	PingInterval  time.Duration                              // PingInterval, if set, is the interval in which pings are sent to the server
+	ReadTimeout   time.Duration                              // ReadTimeout, if set, is the maximal time without any incoming frame, including pings and pongs, before a read fails
+	StaleTimeout  time.Duration                              // StaleTimeout, if set, is the maximal time without a message before a stream is considered stale
+	StaleTimeouts map[string]time.Duration                   // StaleTimeouts overrides StaleTimeout for specific streams, keyed by stream name
+	OnStale       func(stream string, silence time.Duration) // OnStale, if set, is called once each time a stream becomes stale
+	Reconnect     bool                                       // Reconnect indicates whether stale and failed websockets are re-dialed rather than failing the read
+}
+
+// staleTimeout returns the expected maximal interval between messages of the given stream
+func (o *WSOpts) staleTimeout(stream string) time.Duration {
+	if stream == "" {
+		return 0
+	}
+	if timeout, ok := o.StaleTimeouts[stream]; ok {
+		return timeout
+	}
+	return o.StaleTimeout
+}
+
 type wsWrapper struct {
-	conn *websocket.Conn
+	conn   *websocket.Conn
+	addr   string // addr is the address the websocket was dialed to, used for reconnecting
+	stream string // stream is the name of the stream, used for staleness detection
+	dialer *websocket.Dialer
+	opts   WSOpts
+	name   string  // name identifies the websocket to the hooks
+	hooks  wsHooks // hooks observe the connections of the websocket
+
+	mu      sync.Mutex
+	closed  bool
+	down    bool          // down indicates whether the loss of the current connection was reported to the hooks
+	failed  bool          // failed indicates whether the current connection failed and must be re-dialed before reading, only accessed by the reading routine
+	stop    chan struct{} // stop is closed once the websocket is closed, to stop the keepalive and watchdog routines
+	lastMsg int64         // lastMsg is the time of the last read message, in unix nanoseconds
+
+	buf bytes.Buffer // buf is reused by every read, only accessed by the reading routine
+}
+
+func newWSWrapper(conn *websocket.Conn, addr, stream string, dialer *websocket.Dialer, opts WSOpts, hooks wsHooks) *wsWrapper {
+	w := &wsWrapper{
+		conn:   conn,
+		addr:   addr,
+		stream: stream,
+		dialer: dialer,
+		opts:   opts,
+		name:   wsName(addr, stream),
+		hooks:  hooks,
+		stop:   make(chan struct{}),
+	}
+	w.touch()
+	setReadTimeout(conn, opts.ReadTimeout)
+	if opts.PingInterval > 0 {
+		go pingLoop(func() error { return ping(w.current()) }, opts.PingInterval, w.stop)
+	}
+	if timeout := opts.staleTimeout(stream); timeout > 0 {
+		go w.watch(timeout)
+	}
+	return w
+}
+
+// dialWS opens a websocket to the given address, carrying the given stream, observed by the hooks of the client
+// Remark: Staleness detection is disabled for an empty stream name
+func (c *client) dialWS(dialer *websocket.Dialer, opts WSOpts, addr, stream string) (*wsWrapper, error) {
+	hooks := c.observers()
+	conn, _, err := dialer.Dial(addr, nil)
+	hooks.connect(wsName(addr, stream), err)
+	if err != nil {
+		return nil, err
+	}
+	return newWSWrapper(conn, addr, stream, dialer, opts, hooks), nil
 }
 
 func (w *wsWrapper) Close() error {
-	return w.conn.Close()
+	w.mu.Lock()
+	if !w.closed && w.stop != nil {
+		close(w.stop)
+	}
+	w.closed = true
+	conn := w.conn
+	w.mu.Unlock()
+	w.disconnected(nil)
+	return conn.Close()
+}
+
+// read reads the next message from the websocket
+// Remark: If reconnecting is enabled, a failed websocket is re-dialed and the read is retried on the new connection.
+// If re-dialing fails, its error is returned and the next read re-dials again, the failed connection is never read again.
+// The returned data is read into a buffer which is reused by the next read, hence it must not be retained
+func (w *wsWrapper) read() ([]byte, error) {
+	for {
+		if w.failed {
+			if err := w.redial(); err != nil {
+				return nil, err
+			}
+		}
+		conn := w.current()
+		if w.opts.ReadTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(w.opts.ReadTimeout))
+		}
+		// Release the buffer of an exceptionally large message rather than holding it for good
+		if w.buf.Cap() > wsReadBufferMax {
+			w.buf = bytes.Buffer{}
+		}
+		w.buf.Reset()
+		_, r, err := conn.NextReader()
+		if err == nil {
+			_, err = w.buf.ReadFrom(r)
+		}
+		if err == nil {
+			w.touch()
+			w.hooks.message(w.name, w.buf.Bytes())
+			return w.buf.Bytes(), nil
+		}
+		if w.isClosed() {
+			return nil, err
+		}
+		w.disconnected(err)
+		if !w.opts.Reconnect {
+			return nil, err
+		}
+		w.failed = true
+	}
+}
+
+// redial replaces the current connection with a newly dialed one
+func (w *wsWrapper) redial() error {
+	if w.isClosed() {
+		return fmt.Errorf("websocket is closed")
+	}
+	w.current().Close()
+	conn, _, err := w.dialer.Dial(w.addr, nil)
+	w.hooks.connect(w.name, err)
+	if err != nil {
+		return err
+	}
+	setReadTimeout(conn, w.opts.ReadTimeout)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		conn.Close()
+		return fmt.Errorf("websocket is closed")
+	}
+	w.conn = conn
+	w.down = false
+	w.failed = false
+	w.touch()
+	return nil
+}
+
+// disconnected reports the loss of the current connection to the hooks, once per connection
+func (w *wsWrapper) disconnected(err error) {
+	w.mu.Lock()
+	down := w.down
+	w.down = true
+	w.mu.Unlock()
+	if !down {
+		w.hooks.disconnect(w.name, err)
+	}
+}
+
+// watch reports the stream as stale whenever no message was read within the given timeout,
+// and closes the current connection to have it re-dialed if reconnecting is enabled
+func (w *wsWrapper) watch(timeout time.Duration) {
+	interval := timeout / 4
+	if interval < wsWatchIntervalMin {
+		interval = wsWatchIntervalMin
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	stale := false
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+		silence := time.Since(time.Unix(0, atomic.LoadInt64(&w.lastMsg)))
+		if silence < timeout {
+			stale = false
+			continue
+		}
+		if stale {
+			continue
+		}
+		stale = true
+		if w.opts.OnStale != nil {
+			w.opts.OnStale(w.stream, silence)
+		}
+		if w.opts.Reconnect {
+			w.current().Close()
+		}
+	}
+}
+
+func (w *wsWrapper) current() *websocket.Conn {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn
+}
+
+func (w *wsWrapper) isClosed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closed
+}
+
+func (w *wsWrapper) touch() {
+	atomic.StoreInt64(&w.lastMsg, time.Now().UnixNano())
+}
+
+// setReadTimeout extends the read deadline of the given connection by the given timeout whenever a ping or a pong
+// is received, so that control frames keep the connection alive while there are no data messages
+func setReadTimeout(conn *websocket.Conn, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(timeout))
+	})
+	conn.SetPingHandler(func(data string) error {
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(wsWriteWait))
+		if err != nil && err != websocket.ErrCloseSent {
+			return err
+		}
+		return nil
+	})
+}
+
+// pingLoop calls the given ping function in the given interval until stop is closed
+func pingLoop(ping func() error, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ping()
+		}
+	}
+}
+
+// ping sends a ping frame over the given connection
+func ping(conn *websocket.Conn) error {
+	return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
 }
 
 // DepthWS is a wrapper for depth websocket
 type DepthWS struct {
-	wsWrapper
+	*wsWrapper
 }
 
 // Read reads a depth update message from the depth websocket
 func (d *DepthWS) Read() (*DepthUpdate, error) {
-	_, data, err := d.conn.ReadMessage()
+	data, err := d.read()
 	if err != nil {
 		return nil, err
 	}
@@ -30,12 +368,12 @@ func (d *DepthWS) Read() (*DepthUpdate, error) {
 
 // KlinesWS is a wrapper for klines websocket
 type KlinesWS struct {
-	wsWrapper
+	*wsWrapper
 }
 
 // Read reads a klines update message from the klines websocket
 func (d *KlinesWS) Read() (*KlinesUpdate, error) {
-	_, data, err := d.conn.ReadMessage()
+	data, err := d.read()
 	if err != nil {
 		return nil, err
 	}
@@ -45,12 +383,12 @@ func (d *KlinesWS) Read() (*KlinesUpdate, error) {
 
 // TradesWS is a wrapper for trades websocket
 type TradesWS struct {
-	wsWrapper
+	*wsWrapper
 }
 
 // Read reads a trades update message from the trades websocket
 func (d *TradesWS) Read() (*TradesUpdate, error) {
-	_, data, err := d.conn.ReadMessage()
+	data, err := d.read()
 	if err != nil {
 		return nil, err
 	}
@@ -58,30 +396,327 @@ func (d *TradesWS) Read() (*TradesUpdate, error) {
 	return update, json.Unmarshal(data, update)
 }
 
+// PartialDepthWS is a wrapper for partial depth websocket
+type PartialDepthWS struct {
+	*wsWrapper
+}
+
+// Read reads a partial depth update message from the partial depth websocket
+func (d *PartialDepthWS) Read() (*PartialDepthUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &PartialDepthUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// RawTradesWS is a wrapper for raw trades websocket
+type RawTradesWS struct {
+	*wsWrapper
+}
+
+// Read reads a raw trade update message from the raw trades websocket
+func (d *RawTradesWS) Read() (*RawTradeUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &RawTradeUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// TickerWS is a wrapper for ticker websocket
+type TickerWS struct {
+	*wsWrapper
+}
+
+// Read reads a ticker update message from the ticker websocket
+func (d *TickerWS) Read() (*TickerUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &TickerUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// AllTickersWS is a wrapper for all market tickers websocket
+type AllTickersWS struct {
+	*wsWrapper
+}
+
+// Read reads the ticker updates of all symbols which changed, from the all market tickers websocket
+func (d *AllTickersWS) Read() ([]*TickerUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	updates := []*TickerUpdate{}
+	return updates, json.Unmarshal(data, &updates)
+}
+
+// MiniTickerWS is a wrapper for mini ticker websocket
+type MiniTickerWS struct {
+	*wsWrapper
+}
+
+// Read reads a mini ticker update message from the mini ticker websocket
+func (d *MiniTickerWS) Read() (*MiniTickerUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &MiniTickerUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// AllMiniTickersWS is a wrapper for all market mini tickers websocket
+type AllMiniTickersWS struct {
+	*wsWrapper
+}
+
+// Read reads the mini ticker updates of all symbols which changed, from the all market mini tickers websocket
+func (d *AllMiniTickersWS) Read() ([]*MiniTickerUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	updates := []*MiniTickerUpdate{}
+	return updates, json.Unmarshal(data, &updates)
+}
+
+// BookTickerWS is a wrapper for book ticker websocket
+type BookTickerWS struct {
+	*wsWrapper
+}
+
+// Read reads a book ticker update message from the book ticker websocket
+func (d *BookTickerWS) Read() (*BookTickerUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &BookTickerUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// RollingTickerWS is a wrapper for rolling window ticker websocket
+type RollingTickerWS struct {
+	*wsWrapper
+}
+
+// Read reads a rolling window ticker update message from the rolling window ticker websocket
+func (d *RollingTickerWS) Read() (*RollingTickerUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &RollingTickerUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
+// AvgPriceWS is a wrapper for average price websocket
+type AvgPriceWS struct {
+	*wsWrapper
+}
+
+// Read reads an average price update message from the average price websocket
+func (d *AvgPriceWS) Read() (*AvgPriceUpdate, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	update := &AvgPriceUpdate{}
+	return update, json.Unmarshal(data, update)
+}
+
 // AccountInfoWS is a wrapper for account info websocket
 type AccountInfoWS struct {
-	wsWrapper
+	*wsWrapper
 }
 
 // Read reads a account info update message from the account info websocket
 // Remark: The websocket is used to update two different structs, which both are flat, hence every call to this function
-// will return either one of the types initialized and the other one will be set to nil
+// will return either one of the types initialized and the other one will be set to nil.
+// Any other user data event is returned as an *UnexpectedEventError, use ReadEvent to receive all event types
 func (d *AccountInfoWS) Read() (*AccountUpdate, *OrderUpdate, error) {
-	_, data, err := d.conn.ReadMessage()
+	event, err := d.ReadEvent()
 	if err != nil {
 		return nil, nil, err
 	}
+	switch update := event.(type) {
+	case *AccountUpdate:
+		return update, nil, nil
+	case *OrderUpdate:
+		return nil, update, nil
+	}
+	return nil, nil, &UnexpectedEventError{Event: event}
+}
+
+// ReadEvent reads a user data update message of any event type from the account info websocket
+func (d *AccountInfoWS) ReadEvent() (UserDataEvent, error) {
+	data, err := d.read()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeUserDataEvent(data)
+}
+
+// UnexpectedEventError is returned when a received user data event can't be returned by the called function
+type UnexpectedEventError struct {
+	Event UserDataEvent // Event is the received event
+}
+
+func (e *UnexpectedEventError) Error() string {
+	if unknown, ok := e.Event.(*UnknownUserUpdate); ok {
+		return fmt.Sprintf("unknown user data event: %s", unknown.EventType)
+	}
+	return fmt.Sprintf("unexpected user data event: %T", e.Event)
+}
+
+// DecodeUserDataEvent decodes the given user data message according to its event type
+// Remark: Messages of unsupported event types are returned as *UnknownUserUpdate rather than failing
+func DecodeUserDataEvent(data []byte) (UserDataEvent, error) {
 	msgType := &struct {
 		EventType UpdateType `json:"e"` // EventType represents the update type
 		Time      uint64     `json:"E"` // Time represents the event time
 	}{}
 	if err := json.Unmarshal(data, msgType); err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	var event UserDataEvent
+	switch msgType.EventType {
+	case UpdateTypeOutboundAccountInfo:
+		event = &AccountUpdate{}
+	case UpdateTypeOutboundAccountPosition:
+		event = &AccountPositionUpdate{}
+	case UpdateTypeBalanceUpdate:
+		event = &BalanceUpdate{}
+	case UpdateTypeExecutionReport:
+		event = &OrderUpdate{}
+	case UpdateTypeListStatus:
+		event = &ListStatusUpdate{}
+	case UpdateTypeListenKeyExpired:
+		event = &ListenKeyExpiredUpdate{}
+	default:
+		// The data is copied, as it may be a read buffer which is reused
+		return &UnknownUserUpdate{EventType: msgType.EventType, Time: msgType.Time, Data: append([]byte(nil), data...)}, nil
+	}
+	return event, json.Unmarshal(data, event)
+}
+
+// CombinedWS is a wrapper for combined streams websocket
+type CombinedWS struct {
+	*wsWrapper
+}
+
+// Read reads an update message from the combined streams websocket
+// Remark: The payload is decoded according to the stream it was received on, hence only the matching update
+// field of the returned struct is set and the others are nil
+func (c *CombinedWS) Read() (*CombinedUpdate, error) {
+	data, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	return decodeCombinedUpdate(data)
+}
+
+// decodeCombinedUpdate unwraps the combined stream envelope and decodes its payload into the matching update type
+func decodeCombinedUpdate(data []byte) (*CombinedUpdate, error) {
+	envelope := &struct {
+		Stream string          `json:"stream"` // Stream is the name of the stream the payload belongs to
+		Data   json.RawMessage `json:"data"`   // Data is the raw stream payload
+	}{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, err
+	}
+	return decodeStreamUpdate(envelope.Stream, streamType(envelope.Stream), envelope.Data)
+}
+
+// decodeStreamUpdate decodes the given payload of the given stream according to the given stream type
+func decodeStreamUpdate(stream, kind string, data []byte) (*CombinedUpdate, error) {
+	update := &CombinedUpdate{Stream: stream}
+	switch kind {
+	case "depth":
+		update.Depth = &DepthUpdate{}
+		return update, json.Unmarshal(data, update.Depth)
+	case "kline":
+		update.Klines = &KlinesUpdate{}
+		return update, json.Unmarshal(data, update.Klines)
+	case "aggTrade":
+		update.Trades = &TradesUpdate{}
+		return update, json.Unmarshal(data, update.Trades)
+	case "partialDepth":
+		update.PartialDepth = &PartialDepthUpdate{}
+		return update, json.Unmarshal(data, update.PartialDepth)
+	case "trade":
+		update.RawTrade = &RawTradeUpdate{}
+		return update, json.Unmarshal(data, update.RawTrade)
+	case "ticker":
+		update.Ticker = &TickerUpdate{}
+		return update, json.Unmarshal(data, update.Ticker)
+	case "!ticker@arr":
+		return update, json.Unmarshal(data, &update.AllTickers)
+	case "miniTicker":
+		update.MiniTicker = &MiniTickerUpdate{}
+		return update, json.Unmarshal(data, update.MiniTicker)
+	case "!miniTicker@arr":
+		return update, json.Unmarshal(data, &update.AllMiniTickers)
+	case "bookTicker":
+		update.BookTicker = &BookTickerUpdate{}
+		return update, json.Unmarshal(data, update.BookTicker)
+	case "rollingTicker":
+		update.RollingTicker = &RollingTickerUpdate{}
+		return update, json.Unmarshal(data, update.RollingTicker)
+	case "avgPrice":
+		update.AvgPrice = &AvgPriceUpdate{}
+		return update, json.Unmarshal(data, update.AvgPrice)
+	case "markPrice":
+		update.MarkPrice = &MarkPriceUpdate{}
+		return update, json.Unmarshal(data, update.MarkPrice)
+	case "!markPrice@arr":
+		return update, json.Unmarshal(data, &update.AllMarkPrices)
+	case "forceOrder", "!forceOrder@arr":
+		update.Liquidation = &LiquidationUpdate{}
+		return update, json.Unmarshal(data, update.Liquidation)
+	case "continuousKline":
+		update.ContinuousKline = &ContinuousKlineUpdate{}
+		return update, json.Unmarshal(data, update.ContinuousKline)
+	case "indexPriceKline":
+		update.IndexPriceKline = &IndexPriceKlineUpdate{}
+		return update, json.Unmarshal(data, update.IndexPriceKline)
+	case "compositeIndex":
+		update.CompositeIndex = &CompositeIndexUpdate{}
+		return update, json.Unmarshal(data, update.CompositeIndex)
+	}
+	return nil, fmt.Errorf("unsupported stream: %s", stream)
+}
+
+// streamType returns the type of the given stream name, without the symbol and the stream parameters
+// e.g. "ethbtc@kline_1m" is of type "kline" and "ethbtc@depth5@100ms" is of type "partialDepth".
+// All market streams, e.g. "!ticker@arr", are their own type regardless of their update speed
+func streamType(stream string) string {
+	if strings.HasPrefix(stream, "!") {
+		return strings.TrimSuffix(stream, "@"+string(UpdateSpeed1s))
+	}
+	if i := strings.Index(stream, "@"); i >= 0 {
+		stream = stream[i+1:]
+	}
+	if i := strings.Index(stream, "@"); i >= 0 {
+		stream = stream[:i]
 	}
-	if msgType.EventType == UpdateTypeOutboundAccountInfo {
-		update := &AccountUpdate{}
-		return update, nil, json.Unmarshal(data, update)
+	switch {
+	case strings.HasPrefix(stream, "kline_"):
+		return "kline"
+	case strings.HasPrefix(stream, "ticker_"):
+		return "rollingTicker"
+	case strings.HasPrefix(stream, "continuousKline_"):
+		return "continuousKline"
+	case strings.HasPrefix(stream, "indexPriceKline_"):
+		return "indexPriceKline"
+	case strings.HasPrefix(stream, "depth") && stream != "depth":
+		return "partialDepth"
 	}
-	update := &OrderUpdate{}
-	return nil, update, json.Unmarshal(data, update)
+	return stream
 }
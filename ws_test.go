@@ -0,0 +1,267 @@
+package binance
+
+import (
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDecodeCombinedUpdate(t *testing.T) {
+	u, err := decodeCombinedUpdate([]byte(`{"stream":"ethbtc@aggTrade","data":{"e":"aggTrade","E":1,"s":"ETHBTC","a":5,"p":"0.1","q":"2"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "ethbtc@aggTrade", u.Stream)
+	require.NotNil(t, u.Trades)
+	require.Nil(t, u.Depth)
+	require.Nil(t, u.Klines)
+	require.Equal(t, "ETHBTC", u.Trades.Symbol)
+	require.Equal(t, 5, u.Trades.TradeID)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"ethbtc@kline_1m","data":{"e":"kline","s":"ETHBTC","k":{"i":"1m","o":"0.1"}}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.Klines)
+	require.Equal(t, KlineInterval1m, u.Klines.Kline.Interval)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"ethbtc@depth","data":{"e":"depthUpdate","s":"ETHBTC","u":7,"b":[["0.1","2"]],"a":[]}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.Depth)
+	require.Equal(t, "0.1", u.Depth.Bids[0].Price)
+	require.Equal(t, "2", u.Depth.Bids[0].Quantity)
+
+	_, err = decodeCombinedUpdate([]byte(`{"stream":"ethbtc@unknown","data":{}}`))
+	require.Error(t, err)
+}
+
+func TestStreamType(t *testing.T) {
+	for stream, kind := range map[string]string{
+		DepthStream("ETHBTC"):                                          "depth",
+		DepthSpeedStream("ETHBTC", UpdateSpeed100ms):                   "depth",
+		PartialDepthStream("ETHBTC", DepthLevels10, UpdateSpeed1000ms): "partialDepth",
+		PartialDepthStream("ETHBTC", DepthLevels5, UpdateSpeed100ms):   "partialDepth",
+		KlinesStream("ETHBTC", KlineInterval1m):                        "kline",
+		TradesStream("ETHBTC"):                                         "aggTrade",
+		RawTradesStream("ETHBTC"):                                      "trade",
+		TickerStream("ETHBTC"):                                         "ticker",
+		RollingTickerStream("ETHBTC", RollingWindow4h):                 "rollingTicker",
+		AllTickersStream():                                             "!ticker@arr",
+		AllMiniTickersStream():                                         "!miniTicker@arr",
+		BookTickerStream("ETHBTC"):                                     "bookTicker",
+		AvgPriceStream("ETHBTC"):                                       "avgPrice",
+	} {
+		require.Equal(t, kind, streamType(stream), stream)
+	}
+}
+
+func TestDecodeCombinedUpdate_MarketStreams(t *testing.T) {
+	u, err := decodeCombinedUpdate([]byte(`{"stream":"ethbtc@depth5@100ms","data":{"lastUpdateId":3,"bids":[["0.1","2"]],"asks":[["0.2","1"]]}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.PartialDepth)
+	require.Equal(t, 3, u.PartialDepth.LastUpdateID)
+	require.Equal(t, "0.2", u.PartialDepth.Asks[0].Price)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"!miniTicker@arr","data":[{"e":"24hrMiniTicker","s":"ETHBTC","c":"0.1"},{"e":"24hrMiniTicker","s":"LTCBTC","c":"0.2"}]}`))
+	require.NoError(t, err)
+	require.Len(t, u.AllMiniTickers, 2)
+	require.Equal(t, "LTCBTC", u.AllMiniTickers[1].Symbol)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"ethbtc@bookTicker","data":{"u":400900217,"s":"ETHBTC","b":"0.1","B":"31","a":"0.2","A":"40"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.BookTicker)
+	require.Equal(t, "31", u.BookTicker.BidQty)
+	require.Equal(t, "0.1", u.BookTicker.BidPrice)
+
+	u, err = decodeCombinedUpdate([]byte(`{"stream":"ethbtc@ticker_1h","data":{"e":"1hTicker","s":"ETHBTC","p":"0.1","P":"1.5"}}`))
+	require.NoError(t, err)
+	require.NotNil(t, u.RollingTicker)
+	require.Equal(t, "1.5", u.RollingTicker.PriceChangePercent)
+	require.Equal(t, "0.1", u.RollingTicker.PriceChange)
+}
+
+// newSilentServer starts a local server which sends a single message on every connection and then stays silent
+func newSilentServer(t *testing.T) string {
+	upgrader := websocket.Upgrader{}
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		msg := fmt.Sprintf(`{"e":"aggTrade","s":"ETHBTC","a":%d}`, atomic.AddInt32(&connections, 1))
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			return
+		}
+		// Discard control frames without answering pings, as a half-open connection would
+		conn.SetPingHandler(func(string) error { return nil })
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestWSWrapper_ReadTimeout(t *testing.T) {
+	addr := newSilentServer(t)
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	ws := &TradesWS{newWSWrapper(conn, addr, "ethbtc@aggTrade", websocket.DefaultDialer, WSOpts{
+		PingInterval: 20 * time.Millisecond,
+		ReadTimeout:  100 * time.Millisecond,
+	}, nil)}
+	defer ws.Close()
+
+	_, err = ws.Read()
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = ws.Read()
+	require.Error(t, err)
+	require.WithinDuration(t, start.Add(100*time.Millisecond), time.Now(), time.Second)
+}
+
+func TestWSWrapper_StaleReconnect(t *testing.T) {
+	addr := newSilentServer(t)
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	stale := make(chan string, 1)
+	ws := &TradesWS{newWSWrapper(conn, addr, "ethbtc@aggTrade", websocket.DefaultDialer, WSOpts{
+		StaleTimeouts: map[string]time.Duration{"ethbtc@aggTrade": 100 * time.Millisecond},
+		OnStale: func(stream string, silence time.Duration) {
+			stale <- stream
+		},
+		Reconnect: true,
+	}, nil)}
+	defer ws.Close()
+
+	u, err := ws.Read()
+	require.NoError(t, err)
+	require.Equal(t, 1, u.TradeID)
+	u, err = ws.Read()
+	require.NoError(t, err)
+	require.Equal(t, 2, u.TradeID)
+	require.Equal(t, "ethbtc@aggTrade", <-stale)
+}
+
+func TestWSWrapper_TinyStaleTimeout(t *testing.T) {
+	addr := newSilentServer(t)
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	stale := make(chan time.Duration, 1)
+	ws := &TradesWS{newWSWrapper(conn, addr, "ethbtc@aggTrade", websocket.DefaultDialer, WSOpts{
+		StaleTimeout: 3 * time.Nanosecond,
+		OnStale: func(stream string, silence time.Duration) {
+			stale <- silence
+		},
+	}, nil)}
+	defer ws.Close()
+
+	require.True(t, <-stale >= 3*time.Nanosecond)
+}
+
+func TestWSWrapper_RedialFailure(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the first dial succeeds, its connection is closed after a single message
+		if atomic.AddInt32(&connections, 1) > 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"aggTrade","s":"ETHBTC","a":1}`))
+		conn.Close()
+	}))
+	defer server.Close()
+	addr := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(addr, nil)
+	require.NoError(t, err)
+	connects, disconnects := 0, 0
+	ws := &TradesWS{newWSWrapper(conn, addr, "ethbtc@aggTrade", websocket.DefaultDialer, WSOpts{Reconnect: true}, wsHooks{{
+		OnConnect:    func(addr string, err error) { connects++ },
+		OnDisconnect: func(addr string, err error) { disconnects++ },
+	}})}
+	defer ws.Close()
+
+	u, err := ws.Read()
+	require.NoError(t, err)
+	require.Equal(t, 1, u.TradeID)
+	// The failed connection must never be read again, gorilla panics after 1000 reads of a failed connection
+	for i := 0; i < 1100; i++ {
+		_, err := ws.Read()
+		require.Error(t, err)
+	}
+	require.Equal(t, 1100, connects)
+	require.Equal(t, 1, disconnects)
+}
+
+func TestDecodeUserDataEvent(t *testing.T) {
+	event, err := DecodeUserDataEvent([]byte(`{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW",
+		"S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000","g":-1,"C":"",
+		"x":"NEW","X":"NEW","r":"NONE","i":4293153,"l":"0.00000000","z":"0.00000000","L":"0.00000000","n":"0",
+		"N":null,"T":1499405658657,"t":-1,"v":3,"I":8641984,"w":true,"m":false,"M":true,"O":1499405658650,
+		"Z":"0.00000000","Y":"0.00000000","Q":"0.00000000","W":1499405658657,"V":"NONE"}`))
+	require.NoError(t, err)
+	order, ok := event.(*OrderUpdate)
+	require.True(t, ok)
+	require.Equal(t, 4293153, order.OrderID)
+	require.False(t, order.Maker)
+	require.True(t, order.Working)
+	require.Equal(t, uint64(1499405658650), order.OrderTime)
+	require.Equal(t, uint64(1499405658657), order.TradeTime)
+	require.Equal(t, -1, order.OrderListID)
+	require.Equal(t, SelfTradePreventionNone, order.SelfTradePreventionMode)
+
+	// Orders routed by SOR carry keys which differ only in case from other fields
+	event, err = DecodeUserDataEvent([]byte(`{"e":"executionReport","E":1689149087774,"s":"BTCUSDT","c":"sor-order",
+		"S":"BUY","o":"MARKET","f":"GTC","q":"0.02000000","p":"0.00000000","P":"0.00000000","F":"0.00000000","g":-1,"C":"",
+		"x":"TRADE","X":"FILLED","r":"NONE","i":2,"l":"0.02000000","z":"0.02000000","L":"30000.00000000","n":"0","N":"BTC",
+		"T":1689149087774,"t":-1,"v":3,"I":20,"w":false,"m":false,"M":true,"O":1689149087774,"Z":"600.00000000",
+		"Y":"600.00000000","Q":"0.00000000","W":1689149087774,"V":"EXPIRE_TAKER","A":"0.00000000","B":"0.00000000",
+		"b":"ONE_PARTY_TRADE_REPORT","a":1,"k":"SOR","uS":true}`))
+	require.NoError(t, err)
+	order = event.(*OrderUpdate)
+	require.Equal(t, "ONE_PARTY_TRADE_REPORT", order.MatchType)
+	require.Equal(t, 1, order.AllocationID)
+	require.Equal(t, "0.00000000", order.PreventedQty)
+	require.Equal(t, "0.00000000", order.LastPreventedQty)
+
+	event, err = DecodeUserDataEvent([]byte(`{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,
+		"B":[{"a":"ETH","f":"10000.000000","l":"0.000000"}]}`))
+	require.NoError(t, err)
+	position, ok := event.(*AccountPositionUpdate)
+	require.True(t, ok)
+	require.Equal(t, "ETH", position.Balances[0].Asset)
+
+	event, err = DecodeUserDataEvent([]byte(`{"e":"balanceUpdate","E":1573200697110,"a":"BTC","d":"100.00000000","T":1573200697068}`))
+	require.NoError(t, err)
+	require.Equal(t, "100.00000000", event.(*BalanceUpdate).Delta)
+
+	event, err = DecodeUserDataEvent([]byte(`{"e":"listStatus","E":1564035303637,"s":"ETHBTC","g":2,"c":"OCO","l":"EXEC_STARTED",
+		"L":"EXECUTING","r":"NONE","C":"F4QN4G8DlFATFlIUQ0cjdD","T":1564035303625,
+		"O":[{"s":"ETHBTC","i":17,"c":"AJYsMjErWJesZvqlJCTUgL"},{"s":"ETHBTC","i":18,"c":"bfYPSQdLoqAJeNrOr9adzq"}]}`))
+	require.NoError(t, err)
+	list := event.(*ListStatusUpdate)
+	require.Equal(t, ListStatusTypeExecStarted, list.ListStatusType)
+	require.Equal(t, ListOrderStatusExecuting, list.ListOrderStatus)
+	require.Len(t, list.Orders, 2)
+
+	event, err = DecodeUserDataEvent([]byte(`{"e":"listenKeyExpired","E":1576653824250,"listenKey":"key"}`))
+	require.NoError(t, err)
+	require.Equal(t, "key", event.(*ListenKeyExpiredUpdate).ListenKey)
+
+	event, err = DecodeUserDataEvent([]byte(`{"e":"somethingNew","E":5}`))
+	require.NoError(t, err)
+	unknown := event.(*UnknownUserUpdate)
+	require.Equal(t, UpdateType("somethingNew"), unknown.EventType)
+	require.Equal(t, uint64(5), unknown.Time)
+}
@@ -0,0 +1,421 @@
+package binance
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/gorilla/websocket"
+)
+
+const wsAPIAddress = "wss://ws-api.binance.com:443/ws-api/v3"
+
+// wsAPIIntParams are the request parameters sent as JSON numbers rather than strings
+// Remark: Zero values of these parameters are omitted, e.g. the order ID of a cancel by client order ID
+var wsAPIIntParams = map[string]bool{
+	"orderId":     true,
+	"limit":       true,
+	"recvWindow":  true,
+	"fromId":      true,
+	"startTime":   true,
+	"endTime":     true,
+	"orderListId": true,
+}
+
+func (e *WSAPIError) Error() string {
+	return fmt.Sprintf("ws api error %d (status %d): %s", e.Code, e.Status, e.Msg)
+}
+
+type wsAPIRequest struct {
+	ID     int                    `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+type wsAPIResponse struct {
+	Status     int               `json:"status"`
+	Result     json.RawMessage   `json:"result"`
+	Error      *WSAPIError       `json:"error"`
+	RateLimits []*WSAPIRateLimit `json:"rateLimits"`
+}
+
+// WSAPIConn is a websocket API connection, over which requests are sent and their responses are matched by request ID
+// Remark: Signed requests of a logged on session are authenticated by the session, otherwise each of them is signed
+// with the secret of the client
+type WSAPIConn struct {
+	conn   *websocket.Conn
+	apikey string
+	secret string
+	window int
+	opts   WSAPIOpts
+	hooks  wsHooks
+	logger *slog.Logger
+
+	writeMu sync.Mutex // writeMu serializes frames sent over the connection
+
+	mu         sync.Mutex
+	nextID     int
+	pending    map[int]chan *wsAPIResponse
+	rateLimits []*WSAPIRateLimit
+	loggedOn   bool
+	closed     bool
+
+	done chan struct{}
+	err  error
+}
+
+// newWSAPIConn starts serving the given connection
+func newWSAPIConn(conn *websocket.Conn, c *client, opts *WSAPIOpts, wsOpts WSOpts) *WSAPIConn {
+	w := &WSAPIConn{
+		conn:    conn,
+		apikey:  c.apikey,
+		secret:  c.secret,
+		window:  c.window,
+		hooks:   c.observers(),
+		logger:  orDiscard(c.logger),
+		pending: map[int]chan *wsAPIResponse{},
+		done:    make(chan struct{}),
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+	setReadTimeout(conn, wsOpts.ReadTimeout)
+	if wsOpts.PingInterval > 0 {
+		go pingLoop(func() error { return ping(conn) }, wsOpts.PingInterval, w.done)
+	}
+	go w.readLoop(wsOpts.ReadTimeout)
+	return w
+}
+
+// Logon authenticates the session with the Ed25519 private key of the options, after which signed requests no
+// longer have to be signed one by one
+func (w *WSAPIConn) Logon() (*WSAPISession, error) {
+	if w.opts.PrivateKey == nil {
+		return nil, fmt.Errorf("private key is missing")
+	}
+	params := map[string]interface{}{
+		"apiKey":    w.apikey,
+		"timestamp": nowMillis(),
+	}
+	payload := fmt.Sprintf("apiKey=%s&timestamp=%d", w.apikey, params["timestamp"])
+	params["signature"] = base64.StdEncoding.EncodeToString(ed25519.Sign(w.opts.PrivateKey, []byte(payload)))
+	res, err := w.call("session.logon", params)
+	if err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	w.loggedOn = true
+	w.mu.Unlock()
+	resp := &WSAPISession{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// Status get the status of the session
+func (w *WSAPIConn) Status() (*WSAPISession, error) {
+	res, err := w.call("session.status", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := &WSAPISession{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// Depth retrieves the order book for the given symbol
+func (w *WSAPIConn) Depth(opts *DepthOpts) (*Depth, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	res, err := w.request("depth", opts, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Depth{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// Ticker returns 24 hour price change statistics
+func (w *WSAPIConn) Ticker(opts *TickerOpts) (*TickerStats, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	res, err := w.request("ticker.24hr", opts, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &TickerStats{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// NewOrder sends in a new order
+func (w *WSAPIConn) NewOrder(opts *NewOrderOpts) (*NewOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	res, err := w.request("order.place", opts, true)
+	if err != nil {
+		return nil, err
+	}
+	resp := &NewOrder{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// QueryOrder checks an order's status
+func (w *WSAPIConn) QueryOrder(opts *QueryOrderOpts) (*QueryOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	res, err := w.request("order.status", opts, true)
+	if err != nil {
+		return nil, err
+	}
+	resp := &QueryOrder{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// CancelOrder cancel an active order
+func (w *WSAPIConn) CancelOrder(opts *CancelOrderOpts) (*CancelOrder, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("opts is nil")
+	}
+	if opts.OrderID <= 0 && opts.OrigClientOrderId == "" {
+		return nil, fmt.Errorf("order id must be set")
+	}
+	res, err := w.request("order.cancel", opts, true)
+	if err != nil {
+		return nil, err
+	}
+	resp := &CancelOrder{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// Account get account information
+func (w *WSAPIConn) Account() (*AccountInfo, error) {
+	res, err := w.request("account.status", nil, true)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AccountInfo{}
+	return resp, json.Unmarshal(res, resp)
+}
+
+// SubscribeUserData subscribes the logged on session to the user data events of its account, which are delivered to
+// the OnUserData handler of the options, without requiring a datastream key
+func (w *WSAPIConn) SubscribeUserData() error {
+	_, err := w.call("userDataStream.subscribe", nil)
+	return err
+}
+
+// UnsubscribeUserData stops the user data events of the session
+func (w *WSAPIConn) UnsubscribeUserData() error {
+	_, err := w.call("userDataStream.unsubscribe", nil)
+	return err
+}
+
+// RateLimits returns the rate limits usage reported along with the latest response
+func (w *WSAPIConn) RateLimits() []*WSAPIRateLimit {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rateLimits
+}
+
+// Done returns a channel which is closed once the connection terminates
+func (w *WSAPIConn) Done() <-chan struct{} {
+	return w.done
+}
+
+// Close closes the connection
+func (w *WSAPIConn) Close() error {
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+	return w.conn.Close()
+}
+
+// request converts the given opts to request parameters, signing them if required, and sends the request
+func (w *WSAPIConn) request(method string, opts interface{}, sign bool) (json.RawMessage, error) {
+	values, err := query.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	for key := range values {
+		if len(values[key]) > 1 {
+			return nil, fmt.Errorf("repeated %s parameter is not supported", key)
+		}
+		value := values.Get(key)
+		if !wsAPIIntParams[key] {
+			params[key] = value
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", key, err)
+		}
+		if n != 0 {
+			params[key] = n
+		}
+	}
+	if sign {
+		params["timestamp"] = nowMillis()
+		params["recvWindow"] = w.window
+		w.mu.Lock()
+		loggedOn := w.loggedOn
+		w.mu.Unlock()
+		if !loggedOn {
+			params["apiKey"] = w.apikey
+			mac := hmac.New(sha256.New, []byte(w.secret))
+			mac.Write([]byte(signaturePayload(params)))
+			params["signature"] = hex.EncodeToString(mac.Sum(nil))
+		}
+	}
+	return w.call(method, params)
+}
+
+// signaturePayload returns the sorted key=value pairs of the given params joined by "&", as signed by the websocket API
+// Remark: Unlike REST queries, values are signed as sent, without percent-encoding
+func signaturePayload(params map[string]interface{}) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + fmt.Sprint(params[key])
+	}
+	return strings.Join(pairs, "&")
+}
+
+// call sends the given request and waits for its correlated response
+func (w *WSAPIConn) call(method string, params map[string]interface{}) (json.RawMessage, error) {
+	w.mu.Lock()
+	if w.err != nil {
+		w.mu.Unlock()
+		return nil, w.err
+	}
+	w.nextID++
+	id := w.nextID
+	resc := make(chan *wsAPIResponse, 1)
+	w.pending[id] = resc
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		delete(w.pending, id)
+		w.mu.Unlock()
+	}()
+	w.writeMu.Lock()
+	err := w.conn.WriteJSON(&wsAPIRequest{ID: id, Method: method, Params: params})
+	w.writeMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case res := <-resc:
+		if res.Error != nil {
+			res.Error.Status = res.Status
+			return nil, res.Error
+		}
+		return res.Result, nil
+	case <-w.done:
+		return nil, w.err
+	case <-time.After(streamResponseTimeout):
+		return nil, fmt.Errorf("no response for %s request %d", method, id)
+	}
+}
+
+// readLoop reads incoming messages, resolving responses and delivering user data events
+func (w *WSAPIConn) readLoop(readTimeout time.Duration) {
+	for {
+		if readTimeout > 0 {
+			w.conn.SetReadDeadline(time.Now().Add(readTimeout))
+		}
+		_, data, err := w.conn.ReadMessage()
+		if err != nil {
+			w.terminate(err)
+			return
+		}
+		w.hooks.message(wsAPIAddress, data)
+		msg := &struct {
+			ID *int `json:"id"`
+			wsAPIResponse
+			Event json.RawMessage `json:"event"`
+		}{}
+		if err := json.Unmarshal(data, msg); err != nil {
+			w.decodeFailed(err)
+			continue
+		}
+		if msg.ID != nil {
+			w.mu.Lock()
+			if msg.RateLimits != nil {
+				w.rateLimits = msg.RateLimits
+			}
+			resc, ok := w.pending[*msg.ID]
+			w.mu.Unlock()
+			if ok {
+				resc <- &msg.wsAPIResponse
+			}
+			continue
+		}
+		if msg.Event == nil {
+			continue
+		}
+		event, err := DecodeUserDataEvent(msg.Event)
+		if err != nil {
+			w.decodeFailed(err)
+			continue
+		}
+		if w.opts.OnUserData != nil {
+			w.opts.OnUserData(event)
+		}
+	}
+}
+
+// decodeFailed logs and handles the failure to decode a message
+func (w *WSAPIConn) decodeFailed(err error) {
+	w.logger.Warn("binance websocket API decode failed", slog.Any("error", err))
+	w.handleError(err)
+}
+
+func (w *WSAPIConn) handleError(err error) {
+	if w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
+
+// terminate records the error which terminated the connection and releases the pending calls
+func (w *WSAPIConn) terminate(err error) {
+	w.mu.Lock()
+	closed := w.closed
+	w.err = err
+	if closed {
+		w.err = fmt.Errorf("connection closed")
+	}
+	w.mu.Unlock()
+	close(w.done)
+	if closed {
+		w.hooks.disconnect(wsAPIAddress, nil)
+	} else {
+		w.hooks.disconnect(wsAPIAddress, err)
+		w.handleError(err)
+	}
+}
+
+// nowMillis returns the current time in unix milliseconds
+func nowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,161 @@
+package binance
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newWSAPIServer starts a local websocket API server which verifies logons with the given public key, and the
+// signatures of other requests with the given secret. The failed checks of requests are sent to the returned channel
+func newWSAPIServer(publicKey ed25519.PublicKey, secret string) (*httptest.Server, <-chan error) {
+	upgrader := websocket.Upgrader{}
+	errs := make(chan error, 16)
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs <- fmt.Errorf(format, args...)
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		loggedOn := false
+		for {
+			req := &struct {
+				ID     int                    `json:"id"`
+				Method string                 `json:"method"`
+				Params map[string]interface{} `json:"params"`
+			}{}
+			_, reader, err := conn.NextReader()
+			if err != nil {
+				return
+			}
+			// Numbers are kept as sent, for signatures to be verified over their exact text
+			decoder := json.NewDecoder(reader)
+			decoder.UseNumber()
+			if err := decoder.Decode(req); err != nil {
+				return
+			}
+			res := map[string]interface{}{"id": req.ID, "status": 200,
+				"rateLimits": []map[string]interface{}{{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": 6000, "count": req.ID}}}
+			switch req.Method {
+			case "session.logon":
+				payload := fmt.Sprintf("apiKey=%s&timestamp=%s", req.Params["apiKey"], req.Params["timestamp"])
+				signature, _ := base64.StdEncoding.DecodeString(req.Params["signature"].(string))
+				loggedOn = ed25519.Verify(publicKey, []byte(payload), signature)
+				res["result"] = map[string]interface{}{"apiKey": req.Params["apiKey"]}
+			case "order.place":
+				check(req.Params["quantity"] == "0.01", "quantity is %v", req.Params["quantity"])
+				check(req.Params["newClientOrderId"] == "a:b/c+d", "client order id is %v", req.Params["newClientOrderId"])
+				_, ok := req.Params["timestamp"].(json.Number)
+				check(ok, "timestamp %v is not a number", req.Params["timestamp"])
+				if loggedOn {
+					check(req.Params["signature"] == nil, "signature is sent by a logged on session")
+				} else {
+					// The payload is made of the sorted parameters, left unescaped
+					pairs := []string{}
+					for key, value := range req.Params {
+						if key != "signature" {
+							pairs = append(pairs, fmt.Sprintf("%s=%v", key, value))
+						}
+					}
+					sort.Strings(pairs)
+					mac := hmac.New(sha256.New, []byte(secret))
+					mac.Write([]byte(strings.Join(pairs, "&")))
+					signature := hex.EncodeToString(mac.Sum(nil))
+					check(req.Params["signature"] == signature, "signature is %v rather than %s", req.Params["signature"], signature)
+				}
+				res["result"] = map[string]interface{}{"symbol": "BTCUSDT", "orderId": 12510053279}
+			case "order.cancel":
+				check(req.Params["orderId"] == nil, "order id is %v", req.Params["orderId"])
+				res = map[string]interface{}{"id": req.ID, "status": 400, "error": map[string]interface{}{"code": -2011, "msg": "Unknown order sent."}}
+			case "userDataStream.subscribe":
+				res["result"] = map[string]interface{}{}
+				if err := conn.WriteJSON(res); err != nil {
+					return
+				}
+				res = map[string]interface{}{"event": map[string]interface{}{"e": "balanceUpdate", "E": 1, "a": "BTC", "d": "1"}}
+			}
+			if err := conn.WriteJSON(res); err != nil {
+				return
+			}
+		}
+	})), errs
+}
+
+// requireNoServerError fails the test if the server reported a failed check
+func requireNoServerError(t *testing.T, errs <-chan error) {
+	select {
+	case err := <-errs:
+		t.Fatal(err)
+	default:
+	}
+}
+
+func TestWSAPIConn(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	server, errs := newWSAPIServer(publicKey, "secret")
+	defer server.Close()
+
+	events := make(chan UserDataEvent, 1)
+	for _, logon := range []bool{false, true} {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		require.NoError(t, err)
+		w := newWSAPIConn(conn, NewBinanceClient("key", "secret").client, &WSAPIOpts{
+			PrivateKey: privateKey,
+			OnUserData: func(event UserDataEvent) { events <- event },
+		}, WSOpts{})
+		if logon {
+			session, err := w.Logon()
+			require.NoError(t, err)
+			require.Equal(t, "key", session.APIKey)
+		}
+
+		order, err := w.NewOrder(&NewOrderOpts{Symbol: "BTCUSDT", Side: OrderSideBuy, Type: OrderTypeMarket, Quantity: "0.01", NewClientOrderId: "a:b/c+d"})
+		require.NoError(t, err)
+		require.Equal(t, 12510053279, order.OrderID)
+		require.Equal(t, "REQUEST_WEIGHT", w.RateLimits()[0].RateLimitType)
+
+		_, err = w.CancelOrder(&CancelOrderOpts{Symbol: "BTCUSDT", OrigClientOrderId: "unknown"})
+		require.IsType(t, &WSAPIError{}, err)
+		require.Equal(t, -2011, err.(*WSAPIError).Code)
+		require.Equal(t, 400, err.(*WSAPIError).Status)
+		requireNoServerError(t, errs)
+		w.Close()
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	w := newWSAPIConn(conn, NewBinanceClient("key", "secret").client, &WSAPIOpts{
+		OnUserData: func(event UserDataEvent) { events <- event },
+	}, WSOpts{})
+	defer w.Close()
+	require.NoError(t, w.SubscribeUserData())
+	balance, ok := (<-events).(*BalanceUpdate)
+	require.True(t, ok)
+	require.Equal(t, "BTC", balance.Asset)
+	requireNoServerError(t, errs)
+}
+
+func TestWSAPIConn_RepeatedParams(t *testing.T) {
+	w := &WSAPIConn{}
+	_, err := w.request("order.place", &struct {
+		Symbols []string `url:"symbol"`
+	}{[]string{"BTCUSDT", "ETHUSDT"}}, true)
+	require.EqualError(t, err, "repeated symbol parameter is not supported")
+}